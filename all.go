@@ -0,0 +1,26 @@
+//go:build go1.23
+
+package chart
+
+import "iter"
+
+// All returns an iterator over chart labels and values in the same order as
+// [Chart.Labels], with values rounded to the chart's precision:
+//
+//	for label, value := range c.All() {
+//		fmt.Println(label, value)
+//	}
+//
+// Labels and values are read at once when iteration starts, like with
+// [Chart.Snapshot], so they are consistent even if the chart is modified
+// concurrently.
+func (c *Chart) All() iter.Seq2[string, float64] {
+	return func(yield func(string, float64) bool) {
+		labels, values := c.Snapshot()
+		for i, label := range labels {
+			if !yield(label, values[i]) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,82 @@
+//go:build go1.23
+
+package chart_test
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/michenriksen/chart"
+)
+
+func TestChartAll(t *testing.T) {
+	c, err := chart.New(chart.WithSorting(chart.SortByValue, chart.OrderDesc), chart.WithPrecision(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set("a", 1.26).Set("b", 3).Set("c", 2)
+
+	var labels []string
+	var values []float64
+
+	for label, value := range c.All() {
+		labels = append(labels, label)
+		values = append(values, value)
+	}
+
+	if want := c.Labels(); !slices.Equal(labels, want) {
+		t.Errorf("expected labels %v, got %v", want, labels)
+	}
+
+	if want := c.Values(); !slices.Equal(values, want) {
+		t.Errorf("expected values %v, got %v", want, values)
+	}
+
+	// Iteration stops when the loop body breaks.
+	n := 0
+	for range c.All() {
+		n++
+		break
+	}
+
+	if n != 1 {
+		t.Errorf("expected iteration to stop after 1 label, got %d", n)
+	}
+}
+
+func TestChartAllConcurrent(t *testing.T) {
+	c, err := chart.New(chart.WithSorting(chart.SortByValue, chart.OrderDesc))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for i := range 1000 {
+			c.Set("label"+strconv.Itoa(i), float64(i))
+
+			if i%3 == 0 {
+				c.Remove("label" + strconv.Itoa(i/2))
+			}
+		}
+	}()
+
+	for {
+		for label, value := range c.All() {
+			if want := "label" + strconv.FormatFloat(value, 'f', -1, 64); label != want {
+				t.Fatalf("expected label %q for value %v, got %q", want, value, label)
+			}
+		}
+
+		select {
+		case <-done:
+			return
+		default:
+		}
+	}
+}
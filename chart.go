@@ -2,20 +2,24 @@ package chart
 
 import (
 	"cmp"
+	"context"
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"math"
 	"regexp"
 	"slices"
 	"strconv"
 	"strings"
 	"sync"
+	"unicode/utf8"
 )
 
 var (
-	dataSepRE = regexp.MustCompile(`[\s,;:|#]`) // Matches common separator symbols in tabular data.
-	floatRE   = regexp.MustCompile(`[\d\.]`)    // Matches integer and float values.
+	dataSepRE = regexp.MustCompile(`[\s,;:|#]`)        // Matches common separator symbols in tabular data.
+	floatRE   = regexp.MustCompile(`[\d\.]`)           // Matches integer and float values.
+	expRE     = regexp.MustCompile(`\d([eE][+-]?\d+)`) // Matches exponents of values in scientific notation.
 )
 
 // SortOption represents a sort option for a [Chart].
@@ -25,10 +29,12 @@ type SortOption int
 type SortDirection int
 
 const (
-	SortNone           SortOption = iota // No sorting.
-	SortByLabel                          // Sort by label alphabetically.
-	SortByLabelNumeric                   // Sort by label numerically.
-	SortByValue                          // Sort by value.
+	SortNone             SortOption = iota // No sorting.
+	SortByLabel                            // Sort by label alphabetically.
+	SortByLabelNumeric                     // Sort by label numerically.
+	SortByValue                            // Sort by value.
+	SortByValueThenLabel                   // Sort by value, then alphabetically by label.
+	SortByLabelNatural                     // Sort by label in natural order.
 )
 
 const (
@@ -50,11 +56,49 @@ type Renderer interface {
 	Render(*Chart, io.Writer) (int, error)
 }
 
+// ContextRenderer is a [Renderer] that can stop rendering when a context is
+// canceled.
+type ContextRenderer interface {
+	Renderer
+
+	// RenderContext renders the given chart and writes it to the writer like
+	// Render. Returns the context error if ctx is canceled before rendering
+	// is done.
+	RenderContext(context.Context, *Chart, io.Writer) (int, error)
+}
+
+// RenderContext renders the chart with r and writes it to out. Rendering stops
+// when ctx is canceled if r is a [ContextRenderer]. Other renderers are only
+// started if ctx is not canceled.
+func RenderContext(ctx context.Context, r Renderer, c *Chart, out io.Writer) (int, error) {
+	if cr, ok := r.(ContextRenderer); ok {
+		return cr.RenderContext(ctx, c, out)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return 0, fmt.Errorf("rendering chart: %w", err)
+	}
+
+	return r.Render(c, out)
+}
+
+// RenderString renders the chart with r and returns the result as a string.
+func RenderString(r Renderer, c *Chart) (string, error) {
+	var sb strings.Builder
+
+	if _, err := r.Render(c, &sb); err != nil {
+		return "", fmt.Errorf("rendering chart: %w", err)
+	}
+
+	return sb.String(), nil
+}
+
 // orderedMap wraps a map of labels and data to record the order of insertion.
 type orderedMap struct {
-	m  map[string]float64
-	k  []string
-	mu sync.RWMutex
+	m      map[string]float64
+	k      []string
+	sorted []string // Cached sorted keys; nil if map has changed since sorting.
+	mu     sync.RWMutex
 }
 
 func newOrderedMap() *orderedMap {
@@ -65,6 +109,8 @@ func (m *orderedMap) set(key string, val float64) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	m.sorted = nil
+
 	if _, ok := m.m[key]; ok {
 		m.m[key] = val
 		return
@@ -85,6 +131,49 @@ func (m *orderedMap) get(key string) (float64, bool) {
 	return 0, false
 }
 
+func (m *orderedMap) has(key string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	_, ok := m.m[key]
+
+	return ok
+}
+
+func (m *orderedMap) delete(key string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.m[key]; !ok {
+		return false
+	}
+
+	delete(m.m, key)
+	m.sorted = nil
+
+	if i := slices.Index(m.k, key); i != -1 {
+		m.k = slices.Delete(m.k, i, i+1)
+	}
+
+	return true
+}
+
+func (m *orderedMap) clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	clear(m.m)
+	m.k = nil
+	m.sorted = nil
+}
+
+func (m *orderedMap) len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return len(m.k)
+}
+
 func (m *orderedMap) keys() []string {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -95,6 +184,77 @@ func (m *orderedMap) keys() []string {
 	return cp
 }
 
+// sortedKeys returns keys sorted by the sort function. The sorted keys are
+// cached until the map is modified.
+func (m *orderedMap) sortedKeys(sortFn func(keys []string, vals map[string]float64)) []string {
+	m.mu.RLock()
+	if m.sorted != nil {
+		cp := slices.Clone(m.sorted)
+		m.mu.RUnlock()
+
+		return cp
+	}
+	m.mu.RUnlock()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.sorted == nil {
+		sorted := make([]string, len(m.k))
+		copy(sorted, m.k)
+		sortFn(sorted, m.m)
+		m.sorted = sorted
+	}
+
+	return slices.Clone(m.sorted)
+}
+
+// snapshot returns keys sorted by the sort function and their values, read
+// under a single lock so keys and values are consistent.
+func (m *orderedMap) snapshot(sortFn func(keys []string, vals map[string]float64)) ([]string, []float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.sorted == nil {
+		sorted := make([]string, len(m.k))
+		copy(sorted, m.k)
+		sortFn(sorted, m.m)
+		m.sorted = sorted
+	}
+
+	vals := make([]float64, 0, len(m.sorted))
+	for _, k := range m.sorted {
+		vals = append(vals, m.m[k])
+	}
+
+	return slices.Clone(m.sorted), vals
+}
+
+// clone returns a deep copy of the map with its own lock.
+func (m *orderedMap) clone() *orderedMap {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return &orderedMap{
+		m:      maps.Clone(m.m),
+		k:      slices.Clone(m.k),
+		sorted: slices.Clone(m.sorted),
+	}
+}
+
+// entries returns keys and their values in order of insertion.
+func (m *orderedMap) entries() ([]string, []float64) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	vals := make([]float64, 0, len(m.k))
+	for _, k := range m.k {
+		vals = append(vals, m.m[k])
+	}
+
+	return slices.Clone(m.k), vals
+}
+
 func (m *orderedMap) values() []float64 {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -107,20 +267,120 @@ func (m *orderedMap) values() []float64 {
 	return vals
 }
 
+// seriesMap wraps ordered maps of label data keyed by series name to record
+// the order of series insertion.
+type seriesMap struct {
+	m  map[string]*orderedMap
+	k  []string
+	mu sync.RWMutex
+}
+
+func newSeriesMap() *seriesMap {
+	return &seriesMap{m: make(map[string]*orderedMap)}
+}
+
+// data returns the ordered map for a series, creating it if necessary.
+func (s *seriesMap) data(name string) *orderedMap {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if m, ok := s.m[name]; ok {
+		return m
+	}
+
+	m := newOrderedMap()
+	s.k = append(s.k, name)
+	s.m[name] = m
+
+	return m
+}
+
+func (s *seriesMap) get(name, key string) (float64, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if m, ok := s.m[name]; ok {
+		return m.get(key)
+	}
+
+	return 0, false
+}
+
+// sum returns the sum of a key's values across all series.
+func (s *seriesMap) sum(key string) float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sum := 0.0
+	for _, m := range s.m {
+		if val, ok := m.get(key); ok {
+			sum += val
+		}
+	}
+
+	return sum
+}
+
+// delete deletes a key from all series.
+func (s *seriesMap) delete(key string) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, m := range s.m {
+		m.delete(key)
+	}
+}
+
+func (s *seriesMap) clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clear(s.m)
+	s.k = nil
+}
+
+// clone returns a deep copy of the series with its own lock.
+func (s *seriesMap) clone() *seriesMap {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	cp := &seriesMap{m: make(map[string]*orderedMap, len(s.m)), k: slices.Clone(s.k)}
+	for name, m := range s.m {
+		cp.m[name] = m.clone()
+	}
+
+	return cp
+}
+
+func (s *seriesMap) names() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	cp := make([]string, len(s.k))
+	copy(cp, s.k)
+
+	return cp
+}
+
 // Chart represents a simple bar chart.
 type Chart struct {
 	data    *orderedMap
+	series  *seriesMap
 	sort    SortOption
 	sortDir SortDirection
-	p       float64
+	reverse bool
+	prec    int     // Number of digits to round values to.
+	p       float64 // 10 to the power of prec, for rounding.
 }
 
 // New creates a new [Chart] configured with given options.
 func New(opts ...ChartOption) (*Chart, error) {
 	c := &Chart{
 		data:    newOrderedMap(),
+		series:  newSeriesMap(),
 		sort:    DefaultSort,
 		sortDir: DefaultSortDirection,
+		prec:    DefaultPrecision,
 		p:       math.Pow(10, DefaultPrecision),
 	}
 
@@ -133,6 +393,120 @@ func New(opts ...ChartOption) (*Chart, error) {
 	return c, nil
 }
 
+// Histogram creates a new [Chart] configured with given options, with the
+// number of values in each of a number of equal-width bins between the lowest
+// and highest value.
+//
+// Bins are labeled with their value range, e.g. 0-10, and include their lower
+// bound. If the lowest value is negative, ranges are written like -10 to 0 to
+// keep the minus signs apart from the range separator. Range edges are given with as few decimal digits as keep them close
+// to their exact value, regardless of the chart's precision, so all labels are
+// unique. The highest value is counted in the last bin. If all values are
+// equal, the chart has a single bin.
+//
+// Returns an error if bins is not positive, or if bins are too narrow for
+// their edges to be labeled.
+func Histogram(values []float64, bins int, opts ...ChartOption) (*Chart, error) {
+	if bins <= 0 {
+		return nil, errors.New("number of bins must be a positive integer")
+	}
+
+	c, err := New(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(values) == 0 {
+		return c, nil
+	}
+
+	lo, hi := slices.Min(values), slices.Max(values)
+	if lo == hi {
+		edge := strconv.FormatFloat(lo, 'f', -1, 64)
+		return c.Set(edge+binRangeSep(lo)+edge, float64(len(values))), nil
+	}
+
+	edges := make([]float64, bins+1)
+	for i := range bins {
+		edges[i] = lo + (hi-lo)*float64(i)/float64(bins)
+	}
+
+	edges[bins] = hi
+
+	labels, err := binLabels(edges, (hi-lo)/float64(bins))
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make([]float64, bins)
+	for _, val := range values {
+		counts[min(int((val-lo)*float64(bins)/(hi-lo)), bins-1)]++
+	}
+
+	for i, count := range counts {
+		c.Set(labels[i], count)
+	}
+
+	return c, nil
+}
+
+// maxBinDigits is the maximum number of decimal digits of histogram bin edges.
+const maxBinDigits = 15
+
+// binLabels returns labels for histogram bins with the given ascending edges
+// of bins of equal width.
+//
+// Edges are rounded to the fewest decimal digits that keep them within 1% of
+// the bin width of their exact value, so every label is unique and close to
+// the bin's range. Returns an error if edges need more than [maxBinDigits]
+// digits.
+func binLabels(edges []float64, width float64) ([]string, error) {
+	formatted := make([]string, len(edges))
+
+	for digits := 0; digits <= maxBinDigits; digits++ {
+		p := math.Pow(10, float64(digits))
+		ok := true
+
+		for i, edge := range edges {
+			rounded := math.Round(edge*p) / p
+			if math.Abs(rounded-edge) > width/100 {
+				ok = false
+				break
+			}
+
+			if rounded == 0 {
+				rounded = 0 // Avoid labels with negative zero.
+			}
+
+			formatted[i] = strconv.FormatFloat(rounded, 'f', -1, 64)
+		}
+
+		if ok {
+			sep := binRangeSep(edges[0])
+			labels := make([]string, len(edges)-1)
+
+			for i := range labels {
+				labels[i] = formatted[i] + sep + formatted[i+1]
+			}
+
+			return labels, nil
+		}
+	}
+
+	return nil, errors.New("histogram bins are too narrow to be labeled")
+}
+
+// binRangeSep returns the separator between the edges of histogram bin labels
+// for bins starting at lo. A hyphen is ambiguous next to minus signs, so a
+// word is used if any edge can be negative.
+func binRangeSep(lo float64) string {
+	if lo < 0 {
+		return " to "
+	}
+
+	return "-"
+}
+
 // Set sets the value for a label.
 func (c *Chart) Set(label string, value float64) *Chart {
 	c.data.set(label, value)
@@ -149,10 +523,303 @@ func (c *Chart) Add(label string, value float64) *Chart {
 	return c.Set(label, value)
 }
 
+// SetSeries sets the value for a label in a named series.
+//
+// The label's value in the chart is set to the sum of its values across all
+// series, so renderers that can't display multiple series show the total.
+// Setting a label's value with [Chart.Set] or [Chart.Add] changes the total
+// without affecting the label's series values.
+func (c *Chart) SetSeries(label, series string, value float64) *Chart {
+	c.series.data(series).set(label, value)
+	c.data.set(label, c.series.sum(label))
+
+	return c
+}
+
+// Series returns the names of the chart's series in order of insertion.
+// Returns an empty slice if no series values have been set.
+func (c *Chart) Series() []string {
+	return c.series.names()
+}
+
+// SeriesValue returns the value for a label in a named series.
+// Returns 0 if the label exists in the chart but has no value in the series.
+// Returns an error if label does not exist.
+func (c *Chart) SeriesValue(label, series string) (float64, error) {
+	if _, ok := c.data.get(label); !ok {
+		return 0, errors.New("unknown label")
+	}
+
+	val, _ := c.series.get(series, label)
+
+	return c.round(val), nil
+}
+
+// Merge adds the values of another chart to the chart.
+// Values of labels present in both charts are summed, and labels only present
+// in the other chart are added in their order of insertion. Series values are
+// merged the same way, per series. The chart's own sorting and precision
+// options are preserved.
+func (c *Chart) Merge(other *Chart) *Chart {
+	series := other.series.names()
+
+	for _, label := range other.data.keys() {
+		val, ok := other.data.get(label)
+		if !ok {
+			continue
+		}
+
+		for _, name := range series {
+			if sval, ok := other.series.get(name, label); ok {
+				data := c.series.data(name)
+				cur, _ := data.get(label)
+				data.set(label, cur+sval)
+			}
+		}
+
+		c.Add(label, val)
+	}
+
+	return c
+}
+
+// Remove removes a label from the chart.
+// Returns true if the label existed.
+func (c *Chart) Remove(label string) bool {
+	c.series.delete(label)
+	return c.data.delete(label)
+}
+
+// Clear removes all labels and values from the chart.
+// Sorting and precision options are preserved.
+func (c *Chart) Clear() *Chart {
+	c.data.clear()
+	c.series.clear()
+	return c
+}
+
+// Len returns the number of labels in the chart.
+func (c *Chart) Len() int {
+	return c.data.len()
+}
+
+// TopN returns a new chart with the n labels that have the highest values.
+// Ties are broken by order of insertion. The new chart is configured with the
+// same options as the chart. Returns an empty chart if n is not positive.
+func (c *Chart) TopN(n int) *Chart {
+	return c.pick(n, func(i, j float64) int { return cmp.Compare(j, i) })
+}
+
+// TopNWithOther returns a new chart with the n labels that have the highest
+// values, like [Chart.TopN], and the sum of the remaining values collected in a
+// bucket with the given label.
+//
+// The bucket is inserted after the top labels and is sorted like any other
+// label, so it's only guaranteed to be last when the chart is not sorted. The
+// bucket is omitted if no labels remain.
+func (c *Chart) TopNWithOther(n int, label string) *Chart {
+	top := c.TopN(n)
+	rest, hasRest := 0.0, false
+
+	for _, l := range c.data.keys() {
+		if _, ok := top.data.get(l); ok {
+			continue
+		}
+
+		val, _ := c.data.get(l)
+		rest += val
+		hasRest = true
+	}
+
+	if hasRest {
+		top.Add(label, rest)
+	}
+
+	return top
+}
+
+// BottomN returns a new chart with the n labels that have the lowest values.
+// Ties are broken by order of insertion. The new chart is configured with the
+// same options as the chart. Returns an empty chart if n is not positive.
+func (c *Chart) BottomN(n int) *Chart {
+	return c.pick(n, cmp.Compare[float64])
+}
+
+// pick returns a new chart with the first n labels after ranking them by
+// value with the compare function. Labels keep their order of insertion.
+func (c *Chart) pick(n int, compare func(i, j float64) int) *Chart {
+	picked := c.derive()
+	if n <= 0 {
+		return picked
+	}
+
+	labels := c.data.keys()
+	ranked := slices.Clone(labels)
+
+	slices.SortStableFunc(ranked, func(i, j string) int {
+		iVal, _ := c.data.get(i)
+		jVal, _ := c.data.get(j)
+
+		return compare(iVal, jVal)
+	})
+
+	keep := make(map[string]bool, n)
+	for _, label := range ranked[:min(n, len(ranked))] {
+		keep[label] = true
+	}
+
+	series := c.series.names()
+
+	for _, label := range labels {
+		if !keep[label] {
+			continue
+		}
+
+		for _, name := range series {
+			if val, ok := c.series.get(name, label); ok {
+				picked.series.data(name).set(label, val)
+			}
+		}
+
+		val, _ := c.data.get(label)
+		picked.Set(label, val)
+	}
+
+	return picked
+}
+
+// Filter returns a new chart with only the labels for which keep returns true.
+// keep is called with each label and its value rounded to the configured
+// precision. Labels keep their order of insertion, and the new chart is
+// configured with the same options as the chart.
+func (c *Chart) Filter(keep func(label string, value float64) bool) *Chart {
+	filtered := c.derive()
+	series := c.series.names()
+
+	for _, label := range c.data.keys() {
+		val, ok := c.data.get(label)
+		if !ok || !keep(label, c.round(val)) {
+			continue
+		}
+
+		for _, name := range series {
+			if sval, ok := c.series.get(name, label); ok {
+				filtered.series.data(name).set(label, sval)
+			}
+		}
+
+		filtered.Set(label, val)
+	}
+
+	return filtered
+}
+
+// Normalize returns a new chart with values scaled so they sum to total, like
+// 100 for percentages or 1 for fractions. Series values are scaled by the same
+// factor. The new chart is configured with the same options as the chart, which
+// is left unchanged.
+//
+// Values are copied unchanged if the chart's values sum to zero.
+func (c *Chart) Normalize(total float64) *Chart {
+	sum := 0.0
+	for _, val := range c.data.values() {
+		sum += val
+	}
+
+	factor := 1.0
+	if sum != 0 {
+		factor = total / sum
+	}
+
+	return c.Clone().Scale(factor)
+}
+
+// Scale multiplies each value in place by factor, e.g. 1e-6 to convert bytes
+// to megabytes, and returns the chart. Series values are scaled by the same
+// factor.
+func (c *Chart) Scale(factor float64) *Chart {
+	return c.Map(func(_ string, value float64) float64 {
+		return value * factor
+	})
+}
+
+// Map replaces each value in place with the result of calling fn with its
+// label and value, e.g. to take the square root of values, and returns the
+// chart. Labels and their order are unchanged.
+//
+// For labels with series values, fn is also called with each series value.
+// The label's value is transformed on its own, so for non-linear functions it
+// generally differs from the sum of the transformed series values.
+func (c *Chart) Map(fn func(label string, value float64) float64) *Chart {
+	series := c.series.names()
+
+	for _, label := range c.data.keys() {
+		for _, name := range series {
+			if val, ok := c.series.get(name, label); ok {
+				c.series.data(name).set(label, fn(label, val))
+			}
+		}
+
+		if val, ok := c.data.get(label); ok {
+			c.data.set(label, fn(label, val))
+		}
+	}
+
+	return c
+}
+
+// Clone returns a deep copy of the chart with the same labels, values, series
+// values and options. Changes to the clone don't affect the chart, and vice
+// versa.
+func (c *Chart) Clone() *Chart {
+	clone := c.derive()
+	clone.data = c.data.clone()
+	clone.series = c.series.clone()
+
+	return clone
+}
+
+// derive returns a new empty chart configured with the same options as the
+// chart.
+func (c *Chart) derive() *Chart {
+	return &Chart{
+		data:    newOrderedMap(),
+		series:  newSeriesMap(),
+		sort:    c.sort,
+		sortDir: c.sortDir,
+		reverse: c.reverse,
+		prec:    c.prec,
+		p:       c.p,
+	}
+}
+
 // Labels returns chart labels sorted and ordered according to configuration.
 func (c *Chart) Labels() []string {
-	labels := c.data.keys()
+	return c.data.sortedKeys(c.sortLabels)
+}
+
+// Snapshot returns chart labels and their values sorted and ordered according to
+// configuration, like [Chart.Labels] and [Chart.Values].
+//
+// Unlike calling [Chart.Labels] and [Chart.Values] separately, labels and
+// values are read at once, so they are consistent even if the chart is
+// modified concurrently.
+func (c *Chart) Snapshot() (labels []string, values []float64) {
+	labels, values = c.data.snapshot(c.sortLabels)
+	for i, val := range values {
+		values[i] = c.round(val)
+	}
+
+	return labels, values
+}
 
+// Precision returns the number of digits that values are rounded to.
+func (c *Chart) Precision() int {
+	return c.prec
+}
+
+// sortLabels sorts labels in place according to configuration.
+func (c *Chart) sortLabels(labels []string, vals map[string]float64) {
 	switch c.sort {
 	case SortByLabel:
 		slices.SortStableFunc(labels, cmp.Compare)
@@ -162,10 +829,13 @@ func (c *Chart) Labels() []string {
 		})
 	case SortByValue:
 		slices.SortStableFunc(labels, func(i, j string) int {
-			iVal, _ := c.data.get(i)
-			jVal, _ := c.data.get(j)
-
-			return cmp.Compare(iVal, jVal)
+			return cmp.Compare(vals[i], vals[j])
+		})
+	case SortByLabelNatural:
+		slices.SortStableFunc(labels, naturalCompare)
+	case SortByValueThenLabel:
+		slices.SortStableFunc(labels, func(i, j string) int {
+			return cmp.Or(cmp.Compare(vals[i], vals[j]), cmp.Compare(i, j))
 		})
 	}
 
@@ -173,37 +843,167 @@ func (c *Chart) Labels() []string {
 		slices.Reverse(labels)
 	}
 
-	return labels
+	if c.reverse {
+		slices.Reverse(labels)
+	}
+}
+
+// Has returns true if the chart has a label.
+func (c *Chart) Has(label string) bool {
+	return c.data.has(label)
 }
 
 // Value returns the value for a label.
 // Returns an error if label does not exist.
 func (c *Chart) Value(label string) (float64, error) {
 	if val, ok := c.data.get(label); ok {
-		return math.Round(val*c.p) / c.p, nil
+		return c.round(val), nil
 	}
 
 	return 0, errors.New("unknown label")
 }
 
+// ValueOr returns the value for a label, or def if label does not exist.
+func (c *Chart) ValueOr(label string, def float64) float64 {
+	if val, ok := c.data.get(label); ok {
+		return c.round(val)
+	}
+
+	return def
+}
+
+// Values returns chart values in the same order as the labels returned by
+// [Chart.Labels].
+func (c *Chart) Values() []float64 {
+	labels := c.Labels()
+	vals := make([]float64, 0, len(labels))
+
+	for _, label := range labels {
+		val, _ := c.data.get(label)
+		vals = append(vals, c.round(val))
+	}
+
+	return vals
+}
+
 // MaxValue returns the highest chart value.
+// For charts with only negative values, the result is negative.
 func (c *Chart) MaxValue() float64 {
 	vals := c.data.values()
 	if len(vals) == 0 {
 		return 0
 	}
 
-	maxVal := 0.0
-	for _, val := range vals {
+	maxVal := vals[0]
+	for _, val := range vals[1:] {
 		if val > maxVal {
 			maxVal = val
 		}
 	}
 
-	return math.Round(maxVal*c.p) / c.p
+	return c.round(maxVal)
+}
+
+// MinValue returns the lowest chart value.
+func (c *Chart) MinValue() float64 {
+	vals := c.data.values()
+	if len(vals) == 0 {
+		return 0
+	}
+
+	minVal := vals[0]
+	for _, val := range vals[1:] {
+		if val < minVal {
+			minVal = val
+		}
+	}
+
+	return c.round(minVal)
+}
+
+// MaxValueLabel returns the label and value of the highest chart value. If
+// multiple labels have the highest value, the first inserted label is
+// returned. Returns an empty label and zero for empty charts.
+func (c *Chart) MaxValueLabel() (string, float64) {
+	return c.extremeValueLabel(func(a, b float64) bool { return a > b })
+}
+
+// MinValueLabel returns the label and value of the lowest chart value. If
+// multiple labels have the lowest value, the first inserted label is
+// returned. Returns an empty label and zero for empty charts.
+func (c *Chart) MinValueLabel() (string, float64) {
+	return c.extremeValueLabel(func(a, b float64) bool { return a < b })
+}
+
+// extremeValueLabel returns the first inserted label with the value for which
+// better reports true against all other values.
+func (c *Chart) extremeValueLabel(better func(a, b float64) bool) (string, float64) {
+	keys, vals := c.data.entries()
+	if len(keys) == 0 {
+		return "", 0
+	}
+
+	label, val := keys[0], c.round(vals[0])
+	for i, v := range vals[1:] {
+		if v = c.round(v); better(v, val) {
+			label, val = keys[i+1], v
+		}
+	}
+
+	return label, val
+}
+
+// Sum returns the sum of all chart values.
+func (c *Chart) Sum() float64 {
+	sum := 0.0
+	for _, val := range c.data.values() {
+		sum += val
+	}
+
+	return c.round(sum)
+}
+
+// Average returns the arithmetic mean of all chart values.
+// Returns 0 if the chart is empty.
+func (c *Chart) Average() float64 {
+	vals := c.data.values()
+	if len(vals) == 0 {
+		return 0
+	}
+
+	sum := 0.0
+	for _, val := range vals {
+		sum += val
+	}
+
+	return c.round(sum / float64(len(vals)))
 }
 
-// MaxLabel returns the longest chart label.
+// Median returns the median of all chart values.
+// Returns 0 if the chart is empty.
+func (c *Chart) Median() float64 {
+	return c.Percentile(50)
+}
+
+// Percentile returns the p-th percentile of all chart values, where p is
+// between 0 and 100, using linear interpolation between the closest ranks.
+// Values of p outside the range are clamped. Returns 0 if the chart is empty.
+func (c *Chart) Percentile(p float64) float64 {
+	vals := c.data.values()
+	if len(vals) == 0 {
+		return 0
+	}
+
+	slices.Sort(vals)
+
+	rank := min(max(p, 0), 100) / 100 * float64(len(vals)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+
+	return c.round(vals[lower] + (vals[upper]-vals[lower])*(rank-float64(lower)))
+}
+
+// MaxLabel returns the longest chart label, measured in runes.
 func (c *Chart) MaxLabel() string {
 	labels := c.data.keys()
 	if len(labels) == 0 {
@@ -212,7 +1012,7 @@ func (c *Chart) MaxLabel() string {
 
 	maxLabel := ""
 	for _, label := range labels {
-		if len(label) > len(maxLabel) {
+		if utf8.RuneCountInString(label) > utf8.RuneCountInString(maxLabel) {
 			maxLabel = label
 		}
 	}
@@ -220,64 +1020,56 @@ func (c *Chart) MaxLabel() string {
 	return maxLabel
 }
 
+// round rounds a value to the chart's precision.
+func (c *Chart) round(val float64) float64 {
+	return math.Round(val*c.p) / c.p
+}
+
 // ChartOption configures a [Chart].
 type ChartOption func(*Chart) error
 
 // WithSorting configures a [Chart] with bar sorting options.
+// Returns an error if the sort option or direction is unknown.
 func WithSorting(sort SortOption, dir SortDirection) ChartOption {
 	return func(c *Chart) error {
+		if sort < SortNone || sort > SortByLabelNatural {
+			return fmt.Errorf("unknown sort option: %d", sort)
+		}
+
+		if dir < OrderNone || dir > OrderDesc {
+			return fmt.Errorf("unknown sort direction: %d", dir)
+		}
+
 		c.sort = sort
 		c.sortDir = dir
 		return nil
 	}
 }
 
-// WithPrecision configures a [Chart] with a precision for values.
+// WithReversed configures a [Chart] to reverse the order of labels after
+// sorting. Unlike [OrderDesc], this also reverses insertion order when no
+// sorting is configured.
+func WithReversed(enable bool) ChartOption {
+	return func(c *Chart) error {
+		c.reverse = enable
+		return nil
+	}
+}
+
+// WithPrecision configures a [Chart] with a precision for values, as the
+// number of digits to round values to. Negative precision is treated as zero.
 func WithPrecision(p int) ChartOption {
 	return func(c *Chart) error {
 		if p < 0 {
 			p = 0
 		}
 
+		c.prec = p
 		c.p = math.Pow(10, float64(p))
 		return nil
 	}
 }
 
-// ParseLine parses a data line into its float64 value and label string.
-//
-// The line is expected to have the following structure:
-//
-//	<numeric value> <label>
-//
-// The function tolerates any kind of whitespace between the value and label, as
-// well as currency symbols and punctuation.
-func ParseLine(line string) (float64, string, error) {
-	sepIdx := dataSepRE.FindStringIndex(line)
-	if sepIdx == nil {
-		return 0, "", errors.New("missing data separator")
-	}
-
-	value := strings.TrimSpace(line[0:sepIdx[0]])
-	label := strings.TrimSpace(line[sepIdx[1]:])
-
-	if label == "" {
-		return 0, "", errors.New("missing label")
-	}
-
-	value = strings.TrimSuffix(strings.Join(floatRE.FindAllString(value, -1), ""), ".")
-	if value == "" {
-		return 0, "", errors.New("missing value")
-	}
-
-	count, err := strconv.ParseFloat(value, 64)
-	if err != nil {
-		return 0, "", fmt.Errorf("parsing %q as float64: %w", value, err)
-	}
-
-	return count, label, nil
-}
-
 // stringToInt strips all non-numeric characters from a string and converts it
 // to an integer. Returns 0 if conversion fails.
 func stringToInt(s string) int {
@@ -297,3 +1089,55 @@ func stringToInt(s string) int {
 
 	return num
 }
+
+// naturalCompare compares two strings in natural order, where runs of digits
+// are compared numerically and other text is compared lexicographically, so
+// that e.g. "file2" sorts before "file10".
+func naturalCompare(a, b string) int {
+	for a != "" && b != "" {
+		aChunk, aNum := nextChunk(a)
+		bChunk, bNum := nextChunk(b)
+		a, b = a[len(aChunk):], b[len(bChunk):]
+
+		var c int
+		if aNum && bNum {
+			c = compareDigits(aChunk, bChunk)
+		} else {
+			c = cmp.Compare(aChunk, bChunk)
+		}
+
+		if c != 0 {
+			return c
+		}
+	}
+
+	return cmp.Compare(len(a), len(b))
+}
+
+// nextChunk returns the leading run of digits or non-digits in s and whether
+// the run is digits.
+func nextChunk(s string) (string, bool) {
+	isNum := isDigit(s[0])
+
+	i := 1
+	for i < len(s) && isDigit(s[i]) == isNum {
+		i++
+	}
+
+	return s[:i], isNum
+}
+
+// compareDigits compares two strings of digits numerically without converting
+// them to integers, so arbitrarily long numbers can be compared.
+func compareDigits(a, b string) int {
+	a, b = strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+	if c := cmp.Compare(len(a), len(b)); c != 0 {
+		return c
+	}
+
+	return cmp.Compare(a, b)
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
@@ -0,0 +1,1768 @@
+package chart_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/michenriksen/chart"
+	"github.com/michenriksen/chart/csvr"
+	"github.com/michenriksen/chart/simple"
+)
+
+func TestRenderString(t *testing.T) {
+	c, err := chart.New(chart.WithPrecision(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set("a", 2)
+	c.Set("b", 4)
+
+	r, err := simple.NewRenderer(simple.WithMaxLength(8))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := chart.RenderString(r, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "a ▇▇ 2\nb ▇▇▇▇ 4\n"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestMultiRenderer(t *testing.T) {
+	c, err := chart.New(chart.WithPrecision(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set("a", 2)
+	c.Set("b", 4)
+
+	simpleR, err := simple.NewRenderer(simple.WithMaxLength(8))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	csvR, err := csvr.NewRenderer()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var term, file bytes.Buffer
+
+	r := chart.MultiRenderer(
+		chart.RenderTarget{Renderer: simpleR, Writer: &term},
+		chart.RenderTarget{Renderer: csvR, Writer: &file},
+	)
+
+	n, err := r.Render(c, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "a ▇▇ 2\nb ▇▇▇▇ 4\n"; term.String() != want {
+		t.Errorf("expected simple output %q, got %q", want, term.String())
+	}
+
+	if want := "label,value\na,2\nb,4\n"; file.String() != want {
+		t.Errorf("expected CSV output %q, got %q", want, file.String())
+	}
+
+	if want := term.Len() + file.Len(); n != want {
+		t.Errorf("expected %d bytes written, got %d", want, n)
+	}
+}
+
+func TestMultiRendererErrors(t *testing.T) {
+	c, err := chart.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set("a", 1)
+
+	csvR, err := csvr.NewRenderer()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+
+	r := chart.MultiRenderer(
+		chart.RenderTarget{Renderer: csvR, Writer: failingWriter{}},
+		chart.RenderTarget{Renderer: csvR},
+	)
+
+	if _, err := r.Render(c, &out); err == nil || !strings.Contains(err.Error(), "rendering target #1") {
+		t.Errorf("expected error for first target, got %v", err)
+	}
+
+	if out.Len() == 0 {
+		t.Error("expected second target to render to out writer")
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestChartHas(t *testing.T) {
+	c, err := chart.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Has("a") {
+		t.Error("expected chart not to have label before Set")
+	}
+
+	c.Set("a", 1)
+
+	if !c.Has("a") {
+		t.Error("expected chart to have label after Set")
+	}
+
+	c.Remove("a")
+
+	if c.Has("a") {
+		t.Error("expected chart not to have label after Remove")
+	}
+}
+
+func TestChartValueOr(t *testing.T) {
+	c, err := chart.New(chart.WithPrecision(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set("a", 1.26)
+
+	if got := c.ValueOr("a", 42); got != 1.3 {
+		t.Errorf("expected rounded value 1.3 for present label, got %v", got)
+	}
+
+	if got := c.ValueOr("b", 42); got != 42 {
+		t.Errorf("expected default value 42 for absent label, got %v", got)
+	}
+}
+
+func TestChartMaxMinValueLabel(t *testing.T) {
+	c, err := chart.New(chart.WithSorting(chart.SortByLabel, chart.OrderAsc))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if label, value := c.MaxValueLabel(); label != "" || value != 0 {
+		t.Errorf("expected empty chart max to be (\"\", 0), got (%q, %v)", label, value)
+	}
+
+	if label, value := c.MinValueLabel(); label != "" || value != 0 {
+		t.Errorf("expected empty chart min to be (\"\", 0), got (%q, %v)", label, value)
+	}
+
+	// Ties are broken by insertion order regardless of sorting.
+	c.Set("d", 5)
+	c.Set("b", -2)
+	c.Set("c", 9)
+	c.Set("a", 9)
+	c.Set("e", -2)
+
+	if label, value := c.MaxValueLabel(); label != "c" || value != 9 {
+		t.Errorf("expected max (%q, 9), got (%q, %v)", "c", label, value)
+	}
+
+	if label, value := c.MinValueLabel(); label != "b" || value != -2 {
+		t.Errorf("expected min (%q, -2), got (%q, %v)", "b", label, value)
+	}
+}
+
+func TestChartNormalize(t *testing.T) {
+	tests := []struct {
+		name  string
+		total float64
+		want  float64
+	}{
+		{"percentages", 100, 100},
+		{"fractions", 1, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := chart.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			c.Set("a", 1).Set("b", 3).Set("c", 4)
+
+			normalized := c.Normalize(tt.total)
+
+			if got := normalized.Sum(); got != tt.want {
+				t.Errorf("expected normalized sum %v, got %v", tt.want, got)
+			}
+
+			if got := c.Sum(); got != 8 {
+				t.Errorf("expected original chart to be unchanged, got sum %v", got)
+			}
+		})
+	}
+}
+
+func TestChartScale(t *testing.T) {
+	c, err := chart.New(chart.WithPrecision(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set("a", 1500).Set("b", 250).Set("c", -40)
+
+	if got := c.Scale(0.001); got != c {
+		t.Error("expected Scale to return the chart")
+	}
+
+	if got, want := c.Values(), []float64{1.5, 0.25, -0.04}; !slices.Equal(got, want) {
+		t.Errorf("expected values %v, got %v", want, got)
+	}
+
+	if got := c.MaxValue(); got != 1.5 {
+		t.Errorf("expected max value 1.5, got %v", got)
+	}
+}
+
+func TestChartMap(t *testing.T) {
+	c, err := chart.New(chart.WithSorting(chart.SortByValue, chart.OrderDesc))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set("a", 16).Set("b", 81).Set("c", 2.25)
+
+	labels := c.Labels()
+
+	mapped := c.Map(func(_ string, value float64) float64 {
+		return math.Sqrt(value)
+	})
+
+	if mapped != c {
+		t.Error("expected Map to return the chart")
+	}
+
+	if got := c.Labels(); !slices.Equal(got, labels) {
+		t.Errorf("expected labels %v, got %v", labels, got)
+	}
+
+	want := map[string]float64{"a": 4, "b": 9, "c": 1.5}
+	for label, wantVal := range want {
+		if got := c.ValueOr(label, -1); got != wantVal {
+			t.Errorf("expected value %v for %q, got %v", wantVal, label, got)
+		}
+	}
+}
+
+func TestChartMapSeries(t *testing.T) {
+	c, err := chart.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.SetSeries("a", "s1", 16).SetSeries("a", "s2", 9).Set("b", 4)
+	c.SetSeries("c", "s1", 1).SetSeries("c", "s2", 3).Add("c", 32)
+
+	c.Map(func(_ string, value float64) float64 {
+		return math.Sqrt(value)
+	})
+
+	// Totals are transformed themselves, including adjustments made with Set
+	// or Add after setting series values.
+	for label, want := range map[string]float64{"a": 5, "b": 2, "c": 6} {
+		if got := c.ValueOr(label, -1); got != want {
+			t.Errorf("expected value %v for %q, got %v", want, label, got)
+		}
+	}
+
+	for _, tt := range []struct {
+		label, series string
+		want          float64
+	}{
+		{"a", "s1", 4},
+		{"a", "s2", 3},
+		{"c", "s1", 1},
+		{"c", "s2", 1.73},
+	} {
+		if got, _ := c.SeriesValue(tt.label, tt.series); got != tt.want {
+			t.Errorf("expected %q series value %v for %q, got %v", tt.series, tt.want, tt.label, got)
+		}
+	}
+}
+
+func TestChartNormalizeZeroSum(t *testing.T) {
+	c, err := chart.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set("a", -2).Set("b", 2)
+
+	normalized := c.Normalize(100)
+
+	if got := normalized.ValueOr("a", 0); got != -2 {
+		t.Errorf("expected unchanged value -2, got %v", got)
+	}
+
+	if got := normalized.ValueOr("b", 0); got != 2 {
+		t.Errorf("expected unchanged value 2, got %v", got)
+	}
+}
+
+func TestChartPercentile(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		p      float64
+		want   float64
+	}{
+		{"empty", nil, 50, 0},
+		{"single", []float64{7}, 50, 7},
+		{"odd median", []float64{5, 1, 3}, 50, 3},
+		{"even median", []float64{4, 1, 3, 2}, 50, 2.5},
+		{"p0", []float64{5, 1, 3}, 0, 1},
+		{"p100", []float64{5, 1, 3}, 100, 5},
+		{"p25", []float64{1, 2, 3, 4, 5}, 25, 2},
+		{"p90 interpolated", []float64{10, 20, 30, 40}, 90, 37},
+		{"below range", []float64{5, 1, 3}, -10, 1},
+		{"above range", []float64{5, 1, 3}, 110, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := chart.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for i, val := range tt.values {
+				c.Set(string(rune('a'+i)), val)
+			}
+
+			if got := c.Percentile(tt.p); got != tt.want {
+				t.Errorf("expected percentile %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestChartMedian(t *testing.T) {
+	c, err := chart.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := c.Median(); got != 0 {
+		t.Errorf("expected median 0 for empty chart, got %v", got)
+	}
+
+	c.Set("a", 9).Set("b", 1).Set("c", 4).Set("d", 6)
+
+	if got := c.Median(); got != 5 {
+		t.Errorf("expected median 5, got %v", got)
+	}
+
+	c.Set("e", 2)
+
+	if got := c.Median(); got != 4 {
+		t.Errorf("expected median 4, got %v", got)
+	}
+}
+
+func TestChartAverage(t *testing.T) {
+	c, err := chart.New(chart.WithPrecision(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := c.Average(); got != 0 {
+		t.Errorf("expected average 0 for empty chart, got %v", got)
+	}
+
+	c.Set("a", 1).Set("b", 2).Set("c", 2)
+
+	if got := c.Average(); got != 1.67 {
+		t.Errorf("expected rounded average 1.67, got %v", got)
+	}
+
+	c.Set("d", -9)
+
+	if got := c.Average(); got != -1 {
+		t.Errorf("expected average -1, got %v", got)
+	}
+}
+
+func TestParseWithFilter(t *testing.T) {
+	c, err := chart.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	in := strings.NewReader("1 a\n2 b\n3 c\n4 d\n")
+	filter := chart.WithFilter(func(label string, value float64) bool {
+		return label != "b" && value < 4
+	})
+
+	if err := chart.Parse(in, c, filter, chart.WithLimit(2)); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := c.Labels(), []string{"a", "c"}; !slices.Equal(got, want) {
+		t.Errorf("expected labels %v, got %v", want, got)
+	}
+}
+
+func TestParseWithClamp(t *testing.T) {
+	c, err := chart.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	in := strings.NewReader("150 above\n5 below\n50 within\n10 low edge\n100 high edge\n")
+
+	if err := chart.Parse(in, c, chart.WithClamp(10, 100)); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]float64{"above": 100, "below": 10, "within": 50, "low edge": 10, "high edge": 100}
+	for label, wantVal := range want {
+		if got := c.ValueOr(label, -1); got != wantVal {
+			t.Errorf("expected value %v for %q, got %v", wantVal, label, got)
+		}
+	}
+
+	if got := c.MaxValue(); got != 100 {
+		t.Errorf("expected max value 100, got %v", got)
+	}
+}
+
+func TestWithClampInvalidRange(t *testing.T) {
+	c, err := chart.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := chart.Parse(strings.NewReader("1 a\n"), c, chart.WithClamp(2, 1)); err == nil {
+		t.Error("expected error for clamp minimum greater than maximum")
+	}
+}
+
+func TestParseWithCommentPrefix(t *testing.T) {
+	tests := []struct {
+		name   string
+		prefix string
+		in     string
+		want   []string
+	}{
+		{"slashes", "//", "// comment\n1 a\n2 #b\n", []string{"a", "#b"}},
+		{"semicolon", ";", "; comment\n1 a\n;2 b\n3 c\n", []string{"a", "c"}},
+		{"default", chart.DefaultCommentPrefix, "# comment\n42 #1 priority\n", []string{"#1 priority"}},
+		{"disabled", "", "# 1 hash\n1 a\n", []string{"a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := chart.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := chart.Parse(strings.NewReader(tt.in), c, chart.WithCommentPrefix(tt.prefix)); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := c.Labels(); !slices.Equal(got, tt.want) {
+				t.Errorf("expected labels %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseLineWithSeparator(t *testing.T) {
+	tests := []struct {
+		name      string
+		line      string
+		opt       chart.ParseOption
+		wantValue float64
+		wantLabel string
+	}{
+		{"default", "1,234|10:30 standup", nil, 1, "234|10:30 standup"},
+		{"pipe", "1,234|10:30 standup", chart.WithSeparator('|'), 1234, "10:30 standup"},
+		{"tab", "42\thttps://example.com:8080/a;b, c", chart.WithSeparator('\t'), 42, "https://example.com:8080/a;b, c"},
+		{"pattern", "7 | 2024-01-02T10:30:00Z", chart.WithSeparatorPattern(regexp.MustCompile(`\s*\|\s*`)), 7, "2024-01-02T10:30:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []chart.ParseOption
+			if tt.opt != nil {
+				opts = append(opts, tt.opt)
+			}
+
+			value, label, err := chart.ParseLine(tt.line, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if value != tt.wantValue || label != tt.wantLabel {
+				t.Errorf("expected %v %q, got %v %q", tt.wantValue, tt.wantLabel, value, label)
+			}
+		})
+	}
+}
+
+func TestParseLineSigns(t *testing.T) {
+	tests := []struct {
+		line string
+		want float64
+	}{
+		{"-42 label", -42},
+		{"\u221242 label", -42},
+		{"+42 label", 42},
+		{"-$42.50 label", -42.5},
+		{"$-42.50 label", -42.5},
+		{"2024-01-02 label", 20240102},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			value, label, err := chart.ParseLine(tt.line)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if value != tt.want || label != "label" {
+				t.Errorf("expected %v %q, got %v %q", tt.want, "label", value, label)
+			}
+		})
+	}
+}
+
+func TestParseLineScientificNotation(t *testing.T) {
+	tests := []struct {
+		line      string
+		wantValue float64
+		wantLabel string
+	}{
+		{"1.5e3 label", 1500, "label"},
+		{"2E+2 label", 200, "label"},
+		{"-2.5e-3 label", -0.0025, "label"},
+		{"+4e1 label", 40, "label"},
+		{"$1.2e6 revenue", 1.2e6, "revenue"},
+		{"5 e-mail", 5, "e-mail"},
+		{"5 eggs", 5, "eggs"},
+		{"7e label", 7, "label"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			value, label, err := chart.ParseLine(tt.line)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if value != tt.wantValue || label != tt.wantLabel {
+				t.Errorf("expected %v %q, got %v %q", tt.wantValue, tt.wantLabel, value, label)
+			}
+		})
+	}
+}
+
+func TestParseRecordWithFirstToken(t *testing.T) {
+	tests := []struct {
+		line string
+		want chart.Record
+	}{
+		{"1:30 duration", chart.Record{Label: "duration", Value: 1}},
+		{"12:45:30 uptime: total", chart.Record{Label: "uptime: total", Value: 12}},
+		{"3/4 done", chart.Record{Label: "done", Value: 3}},
+		{"16:9 aspect ratio", chart.Record{Label: "aspect ratio", Value: 16}},
+		{"$1,234.50/mo rent", chart.Record{Label: "rent", Value: 1234.5}},
+		{".5 half", chart.Record{Label: "half", Value: 0.5}},
+		{"45% cpu", chart.Record{Label: "cpu", Value: 45, Percent: true}},
+		{"-42 losses", chart.Record{Label: "losses", Value: -42}},
+		{"\u221242 x", chart.Record{Label: "x", Value: -42}},
+		{"+42 x", chart.Record{Label: "x", Value: 42}},
+		{"$-5 z", chart.Record{Label: "z", Value: -5}},
+		{"-2.5e-3 y", chart.Record{Label: "y", Value: -0.0025}},
+		{"1.5e3 x", chart.Record{Label: "x", Value: 1500}},
+		{"2E+2/s rate", chart.Record{Label: "rate", Value: 200}},
+		{"-45% change", chart.Record{Label: "change", Value: -45, Percent: true}},
+		{"2024-01-02 date", chart.Record{Label: "date", Value: 2024}},
+		{"7e label", chart.Record{Label: "label", Value: 7}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			got, err := chart.ParseRecord(tt.line, chart.WithFirstToken(true))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+
+	for _, line := range []string{"42", "n/a label", "42 "} {
+		if _, err := chart.ParseRecord(line, chart.WithFirstToken(true)); err == nil {
+			t.Errorf("expected error for line %q", line)
+		}
+	}
+}
+
+func TestParseLineLabelFirstWithSeparator(t *testing.T) {
+	value, label, err := chart.ParseLineLabelFirst("GET /api:v1 users\t42 ms", chart.WithSeparator('\t'))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if value != 42 || label != "GET /api:v1 users" {
+		t.Errorf("expected 42 %q, got %v %q", "GET /api:v1 users", value, label)
+	}
+}
+
+func TestParseContextCanceled(t *testing.T) {
+	c, err := chart.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	n := 0
+	filter := chart.WithFilter(func(string, float64) bool {
+		if n++; n == 10 {
+			cancel()
+		}
+
+		return true
+	})
+
+	in := strings.NewReader(strings.Repeat("1 a\n", 100_000))
+
+	if err := chart.ParseContext(ctx, in, c, chart.WithSumming(true), filter); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context canceled error, got %v", err)
+	}
+
+	if got := c.ValueOr("a", 0); got != 10 {
+		t.Errorf("expected parsing to stop after 10 lines, got sum %v", got)
+	}
+}
+
+func TestRenderContextCanceled(t *testing.T) {
+	c, err := chart.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set("a", 1)
+
+	r, err := csvr.NewRenderer()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var out bytes.Buffer
+
+	if _, err := chart.RenderContext(ctx, r, c, &out); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context canceled error, got %v", err)
+	}
+
+	if out.Len() != 0 {
+		t.Errorf("expected nothing to be written, got %q", out.String())
+	}
+}
+
+func TestParseWithStrict(t *testing.T) {
+	c, err := chart.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	in := strings.NewReader("1 a\n\n# comment\nbad\n2 b\n")
+
+	err = chart.Parse(in, c, chart.WithStrict(true))
+
+	var lineErr *chart.LineError
+	if !errors.As(err, &lineErr) {
+		t.Fatalf("expected line error, got %v", err)
+	}
+
+	if lineErr.Number != 4 || lineErr.Line != "bad" {
+		t.Errorf("expected line 4 %q, got line %d %q", "bad", lineErr.Number, lineErr.Line)
+	}
+
+	if c.Has("b") {
+		t.Error("expected parsing to stop at first bad line")
+	}
+}
+
+func TestChartFilter(t *testing.T) {
+	c, err := chart.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set("a", 3).Set("b", 1).Set("c", 2)
+
+	filtered := c.Filter(func(_ string, value float64) bool { return value >= 2 })
+
+	if got, want := filtered.Labels(), []string{"a", "c"}; !slices.Equal(got, want) {
+		t.Errorf("expected labels %v, got %v", want, got)
+	}
+
+	if got := c.Len(); got != 3 {
+		t.Errorf("expected original chart to be unchanged, got %d labels", got)
+	}
+}
+
+func TestChartEqual(t *testing.T) {
+	newChart := func(t *testing.T, sort chart.SortOption, set func(c *chart.Chart)) *chart.Chart {
+		t.Helper()
+
+		c, err := chart.New(chart.WithSorting(sort, chart.OrderAsc))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		set(c)
+
+		return c
+	}
+
+	ab := func(c *chart.Chart) { c.Set("a", 1).Set("b", 2) }
+	ba := func(c *chart.Chart) { c.Set("b", 2).Set("a", 1) }
+
+	tests := []struct {
+		name     string
+		a, b     *chart.Chart
+		wantDiff string
+	}{
+		{
+			name: "equal",
+			a:    newChart(t, chart.SortNone, ab),
+			b:    newChart(t, chart.SortNone, ab),
+		},
+		{
+			name: "reordered and sorted",
+			a:    newChart(t, chart.SortByLabel, ab),
+			b:    newChart(t, chart.SortByLabel, ba),
+		},
+		{
+			name:     "reordered",
+			a:        newChart(t, chart.SortNone, ab),
+			b:        newChart(t, chart.SortNone, ba),
+			wantDiff: `labels: ["a" "b"] != ["b" "a"]`,
+		},
+		{
+			name: "within precision",
+			a:    newChart(t, chart.SortNone, ab),
+			b:    newChart(t, chart.SortNone, func(c *chart.Chart) { c.Set("a", 1.001).Set("b", 2) }),
+		},
+		{
+			name:     "different values",
+			a:        newChart(t, chart.SortNone, ab),
+			b:        newChart(t, chart.SortNone, func(c *chart.Chart) { c.Set("a", 1.5).Set("b", 3) }),
+			wantDiff: "value of \"a\": 1 != 1.5\nvalue of \"b\": 2 != 3",
+		},
+		{
+			name:     "different series values",
+			a:        newChart(t, chart.SortNone, func(c *chart.Chart) { c.SetSeries("a", "x", 1) }),
+			b:        newChart(t, chart.SortNone, func(c *chart.Chart) { c.SetSeries("a", "x", 2) }),
+			wantDiff: "value of \"a\": 1 != 2\n\"x\" series value of \"a\": 1 != 2",
+		},
+		{
+			name:     "different options",
+			a:        newChart(t, chart.SortNone, ab),
+			b:        newChart(t, chart.SortByValue, ab),
+			wantDiff: "sort option: 0 != 3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Diff(tt.b); got != tt.wantDiff {
+				t.Errorf("expected diff:\n%s\ngot:\n%s", tt.wantDiff, got)
+			}
+
+			if got, want := tt.a.Equal(tt.b), tt.wantDiff == ""; got != want {
+				t.Errorf("expected Equal to return %t, got %t", want, got)
+			}
+		})
+	}
+}
+
+func TestChartClone(t *testing.T) {
+	c, err := chart.New(chart.WithSorting(chart.SortByValue, chart.OrderDesc), chart.WithPrecision(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set("a", 1).Set("b", 3).SetSeries("c", "x", 2)
+
+	clone := c.Clone()
+	if !clone.Equal(c) {
+		t.Fatalf("expected clone to equal chart:\n%s", clone.Diff(c))
+	}
+
+	clone.Set("a", 10).Set("d", 4).SetSeries("c", "x", 5)
+
+	if got, want := c.Labels(), []string{"b", "c", "a"}; !slices.Equal(got, want) {
+		t.Errorf("expected original labels %v, got %v", want, got)
+	}
+
+	if got := c.ValueOr("a", -1); got != 1 {
+		t.Errorf("expected original value 1 for %q, got %v", "a", got)
+	}
+
+	if got, err := c.SeriesValue("c", "x"); err != nil || got != 2 {
+		t.Errorf("expected original series value 2 for %q, got %v (%v)", "c", got, err)
+	}
+
+	if got, want := clone.Labels(), []string{"a", "c", "d", "b"}; !slices.Equal(got, want) {
+		t.Errorf("expected clone labels %v, got %v", want, got)
+	}
+}
+
+func TestChartJSONRoundTrip(t *testing.T) {
+	c, err := chart.New(chart.WithSorting(chart.SortByValue, chart.OrderDesc), chart.WithPrecision(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set("zeta", 1.26).Set("alpha", 3).Set("mid", 2).SetSeries("alpha", "s1", 1.5)
+
+	b, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var restored chart.Chart
+
+	if err := json.Unmarshal(b, &restored); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := restored.Labels(), c.Labels(); !slices.Equal(got, want) {
+		t.Errorf("expected labels %v, got %v", want, got)
+	}
+
+	for _, label := range c.Labels() {
+		want, _ := c.Value(label)
+
+		got, err := restored.Value(label)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != want {
+			t.Errorf("expected value %v for %q, got %v", want, label, got)
+		}
+	}
+
+	if got, _ := restored.SeriesValue("alpha", "s1"); got != 1.5 {
+		t.Errorf("expected series value 1.5, got %v", got)
+	}
+
+	// Order of insertion is preserved when sorting is disabled.
+	unsorted, err := chart.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err = json.Marshal(unsorted.Set("b", 1).Set("c", 2).Set("a", 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := json.Unmarshal(b, &restored); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := restored.Labels(), []string{"b", "c", "a"}; !slices.Equal(got, want) {
+		t.Errorf("expected labels %v, got %v", want, got)
+	}
+}
+
+func TestChartUnmarshalJSONInvalid(t *testing.T) {
+	var c chart.Chart
+
+	if err := json.Unmarshal([]byte(`{"labels":["a","b"],"values":[1]}`), &c); err == nil {
+		t.Error("expected error for mismatched labels and values")
+	}
+
+	if err := json.Unmarshal([]byte(`{"labels":[],"values":[],"sort":42}`), &c); err == nil {
+		t.Error("expected error for unknown sort option")
+	}
+}
+
+func TestChartGobRoundTrip(t *testing.T) {
+	c, err := chart.New(chart.WithSorting(chart.SortByLabel, chart.OrderAsc), chart.WithPrecision(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set("b", 1.2345).Set("c", 2).Set("a", 3).SetSeries("a", "s1", 0.5)
+
+	var buf bytes.Buffer
+
+	if err := gob.NewEncoder(&buf).Encode(c); err != nil {
+		t.Fatal(err)
+	}
+
+	var restored chart.Chart
+
+	if err := gob.NewDecoder(&buf).Decode(&restored); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := restored.Labels(), []string{"a", "b", "c"}; !slices.Equal(got, want) {
+		t.Errorf("expected labels %v, got %v", want, got)
+	}
+
+	if got, want := restored.Values(), c.Values(); !slices.Equal(got, want) {
+		t.Errorf("expected values %v, got %v", want, got)
+	}
+
+	if got, _ := restored.SeriesValue("a", "s1"); got != 0.5 {
+		t.Errorf("expected series value 0.5, got %v", got)
+	}
+}
+
+func TestChartSnapshotConcurrent(t *testing.T) {
+	c, err := chart.New(chart.WithSorting(chart.SortByValue, chart.OrderDesc))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for i := range 1000 {
+			c.Set("label"+strconv.Itoa(i), float64(i))
+		}
+	}()
+
+	for {
+		labels, values := c.Snapshot()
+
+		if len(labels) != len(values) {
+			t.Fatalf("expected %d values for %d labels, got %d", len(labels), len(labels), len(values))
+		}
+
+		for i, label := range labels {
+			if want := "label" + strconv.FormatFloat(values[i], 'f', -1, 64); label != want {
+				t.Fatalf("expected label %q for value %v, got %q", want, values[i], label)
+			}
+		}
+
+		select {
+		case <-done:
+			return
+		default:
+		}
+	}
+}
+
+func TestChartPrecision(t *testing.T) {
+	c, err := chart.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := c.Precision(); got != chart.DefaultPrecision {
+		t.Errorf("expected default precision %d, got %d", chart.DefaultPrecision, got)
+	}
+
+	tests := []struct {
+		precision int
+		want      int
+	}{
+		{0, 0},
+		{3, 3},
+		{-2, 0},
+	}
+
+	for _, tt := range tests {
+		c, err := chart.New(chart.WithPrecision(tt.precision))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := c.Precision(); got != tt.want {
+			t.Errorf("expected precision %d for WithPrecision(%d), got %d", tt.want, tt.precision, got)
+		}
+
+		if got := c.TopN(1).Precision(); got != tt.want {
+			t.Errorf("expected derived chart precision %d for WithPrecision(%d), got %d", tt.want, tt.precision, got)
+		}
+	}
+}
+
+func TestChartClear(t *testing.T) {
+	c, err := chart.New(chart.WithSorting(chart.SortByValue, chart.OrderDesc), chart.WithPrecision(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set("a", 1).Set("b", 2)
+
+	if got := c.Clear(); got != c {
+		t.Error("expected Clear to return the chart")
+	}
+
+	if got := c.Labels(); len(got) != 0 {
+		t.Errorf("expected no labels after Clear, got %v", got)
+	}
+
+	c.Set("x", 1.26).Set("y", 3).Set("z", 2)
+
+	if got, want := c.Labels(), []string{"y", "z", "x"}; !slices.Equal(got, want) {
+		t.Errorf("expected sorting to be preserved with labels %v, got %v", want, got)
+	}
+
+	if got := c.ValueOr("x", 0); got != 1.3 {
+		t.Errorf("expected precision to be preserved with value 1.3, got %v", got)
+	}
+}
+
+func TestChartMinValue(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		want   float64
+	}{
+		{"empty", nil, 0},
+		{"positive", []float64{4, 2, 8}, 2},
+		{"mixed", []float64{4, -2, 8}, -2},
+		{"all negative", []float64{-10, -3, -50}, -50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := chart.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for i, val := range tt.values {
+				c.Set(strconv.Itoa(i), val)
+			}
+
+			if got := c.MinValue(); got != tt.want {
+				t.Errorf("expected min value %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestChartMaxValue(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		want   float64
+	}{
+		{"empty", nil, 0},
+		{"positive", []float64{4, 2, 8}, 8},
+		{"mixed", []float64{-4, 2, -8}, 2},
+		{"all negative", []float64{-10, -3, -50}, -3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := chart.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for i, val := range tt.values {
+				c.Set(strconv.Itoa(i), val)
+			}
+
+			if got := c.MaxValue(); got != tt.want {
+				t.Errorf("expected max value %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestChartMerge(t *testing.T) {
+	tests := []struct {
+		name       string
+		other      map[string]float64
+		otherOrder []string
+		wantLabels []string
+		wantValues []float64
+	}{
+		{
+			name:       "overlapping",
+			other:      map[string]float64{"b": 3, "a": 1.5},
+			otherOrder: []string{"b", "a"},
+			wantLabels: []string{"a", "b"},
+			wantValues: []float64{2.5, 5},
+		},
+		{
+			name:       "disjoint",
+			other:      map[string]float64{"d": 4, "c": 3},
+			otherOrder: []string{"d", "c"},
+			wantLabels: []string{"a", "b", "d", "c"},
+			wantValues: []float64{1, 2, 4, 3},
+		},
+		{
+			name:       "partly overlapping",
+			other:      map[string]float64{"c": 3, "a": -1},
+			otherOrder: []string{"c", "a"},
+			wantLabels: []string{"a", "b", "c"},
+			wantValues: []float64{0, 2, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := chart.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			c.Set("a", 1).Set("b", 2)
+
+			other, err := chart.New(chart.WithSorting(chart.SortByLabel, chart.OrderDesc), chart.WithPrecision(0))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, label := range tt.otherOrder {
+				other.Set(label, tt.other[label])
+			}
+
+			if got := c.Merge(other); got != c {
+				t.Error("expected Merge to return the chart")
+			}
+
+			labels, values := c.Snapshot()
+
+			if !slices.Equal(labels, tt.wantLabels) {
+				t.Errorf("expected labels %v, got %v", tt.wantLabels, labels)
+			}
+
+			if !slices.Equal(values, tt.wantValues) {
+				t.Errorf("expected values %v, got %v", tt.wantValues, values)
+			}
+
+			if got := other.Len(); got != len(tt.otherOrder) {
+				t.Errorf("expected other chart to be unchanged, got %d labels", got)
+			}
+		})
+	}
+}
+
+func TestParseLineOrderings(t *testing.T) {
+	tests := []struct {
+		name       string
+		line       string
+		labelFirst bool
+		wantValue  float64
+		wantLabel  string
+	}{
+		{"value first", "42 requests", false, 42, "requests"},
+		{"value first label with digits", "42 HTTP 404 errors", false, 42, "HTTP 404 errors"},
+		{"value first label with trailing number", "3.5 server 2", false, 3.5, "server 2"},
+		{"label first", "requests 42", true, 42, "requests"},
+		{"label first label with digits", "HTTP 404 errors 17", true, 17, "HTTP 404 errors"},
+		{"label first label with leading number", "2024 revenue $1500", true, 1500, "2024 revenue"},
+		{"label first with separators", "disk: /dev/sda1, 75.5", true, 75.5, "disk: /dev/sda1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parse := chart.ParseLine
+			if tt.labelFirst {
+				parse = chart.ParseLineLabelFirst
+			}
+
+			value, label, err := parse(tt.line)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if value != tt.wantValue || label != tt.wantLabel {
+				t.Errorf("expected %v %q, got %v %q", tt.wantValue, tt.wantLabel, value, label)
+			}
+		})
+	}
+
+	if _, _, err := chart.ParseLineLabelFirst("requests"); err == nil {
+		t.Error("expected error for label-first line without separator")
+	}
+}
+
+func TestParseLineWithNumberSeparators(t *testing.T) {
+	tests := []struct {
+		name      string
+		line      string
+		thousands rune
+		decimal   rune
+		wantValue float64
+		wantLabel string
+	}{
+		{"US", "1,234,567.89 requests", ',', '.', 1234567.89, "requests"},
+		{"US without grouping", "1234567.89 requests", ',', '.', 1234567.89, "requests"},
+		{"EU", "1.234,56 €", '.', ',', 1234.56, "€"},
+		{"EU large", "1.234.567 requests", '.', ',', 1234567, "requests"},
+		{"EU with space grouping", "1 234,56 €", ' ', ',', 1234.56, "€"},
+		{"Indian", "12,34,567.89 rupees", ',', '.', 1234567.89, "rupees"},
+		{"no grouping", "1234,5 km", 0, ',', 1234.5, "km"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, label, err := chart.ParseLine(tt.line, chart.WithNumberSeparators(tt.thousands, tt.decimal))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if value != tt.wantValue || label != tt.wantLabel {
+				t.Errorf("expected %v %q, got %v %q", tt.wantValue, tt.wantLabel, value, label)
+			}
+		})
+	}
+
+	for _, seps := range [][2]rune{{',', ','}, {',', 0}} {
+		if _, _, err := chart.ParseLine("1 a", chart.WithNumberSeparators(seps[0], seps[1])); err == nil {
+			t.Errorf("expected error for separators %q", seps)
+		}
+	}
+}
+
+func TestParseRecordPercentages(t *testing.T) {
+	tests := []struct {
+		line      string
+		fractions bool
+		want      chart.Record
+	}{
+		{"45% success", false, chart.Record{Label: "success", Value: 45, Percent: true}},
+		{"%45 success", false, chart.Record{Label: "success", Value: 45, Percent: true}},
+		{"45 success", false, chart.Record{Label: "success", Value: 45}},
+		{"45% success", true, chart.Record{Label: "success", Value: 0.45, Percent: true}},
+		{"%45 success", true, chart.Record{Label: "success", Value: 0.45, Percent: true}},
+		{"45 success", true, chart.Record{Label: "success", Value: 45}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			got, err := chart.ParseRecord(tt.line, chart.WithPercentFractions(tt.fractions))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParsePercentagesMixed(t *testing.T) {
+	c, err := chart.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := "45% success\n0.1 errors\n%5 retries\n"
+
+	if err := chart.Parse(strings.NewReader(data), c, chart.WithPercentFractions(true)); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := c.Values(), []float64{0.45, 0.1, 0.05}; !slices.Equal(got, want) {
+		t.Errorf("expected values %v, got %v", want, got)
+	}
+}
+
+func TestChartSetSeries(t *testing.T) {
+	c, err := chart.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := c.Series(); len(got) != 0 {
+		t.Errorf("expected no series, got %v", got)
+	}
+
+	c.SetSeries("Q1", "2024", 12).
+		SetSeries("Q1", "2023", 10).
+		SetSeries("Q2", "2023", 8).
+		SetSeries("Q1", "2024", 13)
+
+	if got, want := c.Series(), []string{"2024", "2023"}; !slices.Equal(got, want) {
+		t.Errorf("expected series %v, got %v", want, got)
+	}
+
+	if got, want := c.Values(), []float64{23, 8}; !slices.Equal(got, want) {
+		t.Errorf("expected summed values %v, got %v", want, got)
+	}
+
+	tests := []struct {
+		label, series string
+		want          float64
+	}{
+		{"Q1", "2023", 10},
+		{"Q1", "2024", 13},
+		{"Q2", "2023", 8},
+		{"Q2", "2024", 0},
+	}
+
+	for _, tt := range tests {
+		got, err := c.SeriesValue(tt.label, tt.series)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != tt.want {
+			t.Errorf("expected %q series value %v for %q, got %v", tt.series, tt.want, tt.label, got)
+		}
+	}
+
+	if _, err := c.SeriesValue("Q3", "2023"); err == nil {
+		t.Error("expected error for unknown label")
+	}
+
+	c.Set("Q1", 100)
+
+	if got, _ := c.SeriesValue("Q1", "2023"); got != 10 {
+		t.Errorf("expected Set not to affect series value, got %v", got)
+	}
+}
+
+func TestChartLabelsValuesAligned(t *testing.T) {
+	sorts := []struct {
+		name string
+		sort chart.SortOption
+	}{
+		{"none", chart.SortNone},
+		{"label", chart.SortByLabel},
+		{"label numeric", chart.SortByLabelNumeric},
+		{"value", chart.SortByValue},
+		{"value then label", chart.SortByValueThenLabel},
+		{"label natural", chart.SortByLabelNatural},
+	}
+
+	dirs := []chart.SortDirection{chart.OrderNone, chart.OrderAsc, chart.OrderDesc}
+
+	for _, s := range sorts {
+		for _, dir := range dirs {
+			t.Run(s.name+"/"+strconv.Itoa(int(dir)), func(t *testing.T) {
+				c, err := chart.New(chart.WithSorting(s.sort, dir))
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				c.Set("item 10", 3).Set("item 2", 1).Set("b", 3).Set("a", 7).Set("item 1", -2)
+
+				labels, values := c.Labels(), c.Values()
+
+				if len(labels) != len(values) {
+					t.Fatalf("expected %d values for %d labels, got %d", len(labels), len(labels), len(values))
+				}
+
+				for i, label := range labels {
+					if want := c.ValueOr(label, math.NaN()); values[i] != want {
+						t.Errorf("expected value %v at index %d for %q, got %v", want, i, label, values[i])
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestChartLabelsCacheInvalidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(c *chart.Chart)
+		want   []string
+	}{
+		{"Set new label", func(c *chart.Chart) { c.Set("d", 4) }, []string{"d", "c", "b", "a"}},
+		{"Set existing label", func(c *chart.Chart) { c.Set("a", 10) }, []string{"a", "c", "b"}},
+		{"Add", func(c *chart.Chart) { c.Add("b", 5) }, []string{"b", "c", "a"}},
+		{"SetSeries", func(c *chart.Chart) { c.SetSeries("a", "s", 9) }, []string{"a", "c", "b"}},
+		{"Remove", func(c *chart.Chart) { c.Remove("c") }, []string{"b", "a"}},
+		{"Clear", func(c *chart.Chart) { c.Clear() }, []string{}},
+		{"Merge", func(c *chart.Chart) {
+			other, _ := chart.New()
+			c.Merge(other.Set("a", 5).Set("e", 2.5))
+		}, []string{"a", "c", "e", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := chart.New(chart.WithSorting(chart.SortByValue, chart.OrderDesc))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			c.Set("a", 1).Set("b", 2).Set("c", 3)
+
+			// Populate the cache before mutating.
+			if got, want := c.Labels(), []string{"c", "b", "a"}; !slices.Equal(got, want) {
+				t.Fatalf("expected labels %v, got %v", want, got)
+			}
+
+			tt.mutate(c)
+
+			if got := c.Labels(); !slices.Equal(got, tt.want) {
+				t.Errorf("expected labels %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestChartLabelsCacheCopy(t *testing.T) {
+	c, err := chart.New(chart.WithSorting(chart.SortByLabel, chart.OrderAsc))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set("b", 1).Set("a", 2)
+
+	c.Labels()[0] = "changed"
+
+	if got, want := c.Labels(), []string{"a", "b"}; !slices.Equal(got, want) {
+		t.Errorf("expected cached labels to be unaffected by callers, got %v", got)
+	}
+}
+
+func TestChartLabelsConcurrent(t *testing.T) {
+	c, err := chart.New(chart.WithSorting(chart.SortByLabelNatural, chart.OrderAsc))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range 100 {
+		c.Set("label"+strconv.Itoa(i), float64(i))
+	}
+
+	var wg sync.WaitGroup
+
+	for range 8 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range 100 {
+				if labels := c.Labels(); len(labels) < 100 {
+					t.Errorf("expected at least 100 labels, got %d", len(labels))
+					return
+				}
+
+				c.Set("new"+strconv.Itoa(i), float64(i))
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if got := c.Len(); got != 200 {
+		t.Errorf("expected 200 labels, got %d", got)
+	}
+}
+
+func TestWithSortingInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		sort chart.SortOption
+		dir  chart.SortDirection
+	}{
+		{"unknown sort option", chart.SortOption(99), chart.OrderAsc},
+		{"negative sort option", chart.SortOption(-1), chart.OrderAsc},
+		{"unknown direction", chart.SortByLabel, chart.SortDirection(99)},
+		{"negative direction", chart.SortByLabel, chart.SortDirection(-1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := chart.New(chart.WithSorting(tt.sort, tt.dir)); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	tests := []struct {
+		name       string
+		values     []float64
+		bins       int
+		wantLabels []string
+		wantCounts []float64
+	}{
+		{
+			name:       "integer range",
+			values:     []float64{0, 1, 2, 3, 4, 5, 6, 7, 8},
+			bins:       4,
+			wantLabels: []string{"0-2", "2-4", "4-6", "6-8"},
+			wantCounts: []float64{2, 2, 2, 3},
+		},
+		{
+			name:       "fractional range",
+			values:     []float64{0, 0.1, 0.25, 0.3, 0.5, 0.75, 1},
+			bins:       4,
+			wantLabels: []string{"0-0.25", "0.25-0.5", "0.5-0.75", "0.75-1"},
+			wantCounts: []float64{2, 2, 1, 2},
+		},
+		{
+			name:       "more bins than integer values",
+			values:     []float64{0, 1, 2, 3, 4, 5},
+			bins:       10,
+			wantLabels: []string{"0-0.5", "0.5-1", "1-1.5", "1.5-2", "2-2.5", "2.5-3", "3-3.5", "3.5-4", "4-4.5", "4.5-5"},
+			wantCounts: []float64{1, 0, 1, 0, 1, 0, 1, 0, 1, 1},
+		},
+		{
+			name:       "negative range",
+			values:     []float64{-1, -0.5, 0, 1},
+			bins:       2,
+			wantLabels: []string{"-1 to 0", "0 to 1"},
+			wantCounts: []float64{2, 2},
+		},
+		{
+			name:       "negative bounds",
+			values:     []float64{-3, -2, -1.5, -0.5},
+			bins:       2,
+			wantLabels: []string{"-3 to -1.75", "-1.75 to -0.5"},
+			wantCounts: []float64{2, 2},
+		},
+		{
+			name:       "all equal negative",
+			values:     []float64{-2, -2},
+			bins:       3,
+			wantLabels: []string{"-2 to -2"},
+			wantCounts: []float64{2},
+		},
+		{
+			name:       "max value in last bin",
+			values:     []float64{0, 10},
+			bins:       5,
+			wantLabels: []string{"0-2", "2-4", "4-6", "6-8", "8-10"},
+			wantCounts: []float64{1, 0, 0, 0, 1},
+		},
+		{
+			name:       "all equal",
+			values:     []float64{2.5, 2.5, 2.5},
+			bins:       4,
+			wantLabels: []string{"2.5-2.5"},
+			wantCounts: []float64{3},
+		},
+		{
+			name:       "empty",
+			bins:       4,
+			wantLabels: []string{},
+			wantCounts: []float64{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := chart.Histogram(tt.values, tt.bins, chart.WithPrecision(0))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			labels, counts := c.Snapshot()
+
+			if !slices.Equal(labels, tt.wantLabels) {
+				t.Errorf("expected labels %q, got %q", tt.wantLabels, labels)
+			}
+
+			if !slices.Equal(counts, tt.wantCounts) {
+				t.Errorf("expected counts %v, got %v", tt.wantCounts, counts)
+			}
+		})
+	}
+}
+
+func TestHistogramErrors(t *testing.T) {
+	for _, bins := range []int{0, -1} {
+		if _, err := chart.Histogram([]float64{1, 2}, bins); err == nil {
+			t.Errorf("expected error for %d bins", bins)
+		}
+	}
+
+	if _, err := chart.Histogram([]float64{1, math.Nextafter(1, 2)}, 10); err == nil {
+		t.Error("expected error for bins too narrow to be labeled")
+	}
+}
+
+func TestChartBottomN(t *testing.T) {
+	tests := []struct {
+		name       string
+		n          int
+		wantLabels []string
+		wantValues []float64
+	}{
+		{"fewer", 2, []string{"b", "d"}, []float64{1, -4}},
+		{"ties broken by insertion", 3, []string{"b", "c", "d"}, []float64{1, 3, -4}},
+		{"equal to length", 5, []string{"a", "b", "c", "d", "e"}, []float64{5, 1, 3, -4, 3}},
+		{"more than length", 10, []string{"a", "b", "c", "d", "e"}, []float64{5, 1, 3, -4, 3}},
+		{"zero", 0, []string{}, []float64{}},
+		{"negative", -1, []string{}, []float64{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := chart.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			c.Set("a", 5).Set("b", 1).Set("c", 3).Set("d", -4).Set("e", 3)
+
+			labels, values := c.BottomN(tt.n).Snapshot()
+
+			if !slices.Equal(labels, tt.wantLabels) {
+				t.Errorf("expected labels %v, got %v", tt.wantLabels, labels)
+			}
+
+			if !slices.Equal(values, tt.wantValues) {
+				t.Errorf("expected values %v, got %v", tt.wantValues, values)
+			}
+
+			if got := c.Len(); got != 5 {
+				t.Errorf("expected original chart to be unchanged, got %d labels", got)
+			}
+		})
+	}
+}
+
+func TestChartTopNWithOther(t *testing.T) {
+	tests := []struct {
+		name       string
+		n          int
+		wantLabels []string
+		wantValues []float64
+	}{
+		{"fewer", 2, []string{"a", "c", "other"}, []float64{5, 3, 0}},
+		{"ties broken by insertion", 1, []string{"a", "other"}, []float64{5, 3}},
+		{"equal to length", 5, []string{"a", "b", "c", "d", "e"}, []float64{5, 1, 3, -4, 3}},
+		{"more than length", 10, []string{"a", "b", "c", "d", "e"}, []float64{5, 1, 3, -4, 3}},
+		{"zero", 0, []string{"other"}, []float64{8}},
+		{"negative", -1, []string{"other"}, []float64{8}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := chart.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			c.Set("a", 5).Set("b", 1).Set("c", 3).Set("d", -4).Set("e", 3)
+
+			labels, values := c.TopNWithOther(tt.n, "other").Snapshot()
+
+			if !slices.Equal(labels, tt.wantLabels) {
+				t.Errorf("expected labels %v, got %v", tt.wantLabels, labels)
+			}
+
+			if !slices.Equal(values, tt.wantValues) {
+				t.Errorf("expected values %v, got %v", tt.wantValues, values)
+			}
+		})
+	}
+}
+
+func TestChartMergeSeries(t *testing.T) {
+	c, err := chart.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.SetSeries("Q1", "2023", 10).SetSeries("Q2", "2023", 8)
+
+	other, err := chart.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other.SetSeries("Q1", "2023", 1).SetSeries("Q1", "2024", 12).SetSeries("Q3", "2024", 15)
+
+	c.Merge(other)
+
+	if got, want := c.Series(), []string{"2023", "2024"}; !slices.Equal(got, want) {
+		t.Errorf("expected series %v, got %v", want, got)
+	}
+
+	if got, want := c.Labels(), []string{"Q1", "Q2", "Q3"}; !slices.Equal(got, want) {
+		t.Errorf("expected labels %v, got %v", want, got)
+	}
+
+	if got, want := c.Values(), []float64{23, 8, 15}; !slices.Equal(got, want) {
+		t.Errorf("expected values %v, got %v", want, got)
+	}
+
+	tests := []struct {
+		label, series string
+		want          float64
+	}{
+		{"Q1", "2023", 11},
+		{"Q1", "2024", 12},
+		{"Q2", "2023", 8},
+		{"Q2", "2024", 0},
+		{"Q3", "2023", 0},
+		{"Q3", "2024", 15},
+	}
+
+	for _, tt := range tests {
+		if got, _ := c.SeriesValue(tt.label, tt.series); got != tt.want {
+			t.Errorf("expected %q series value %v for %q, got %v", tt.series, tt.want, tt.label, got)
+		}
+	}
+}
+
+func TestWithLimit(t *testing.T) {
+	c, err := chart.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A limit of zero means no limit.
+	if err := chart.Parse(strings.NewReader("1 a\n2 b\n3 c\n"), c, chart.WithLimit(0)); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := c.Len(); got != 3 {
+		t.Errorf("expected 3 labels without limit, got %d", got)
+	}
+
+	err = chart.Parse(strings.NewReader("1 a\n"), c, chart.WithLimit(-1))
+	if err == nil || !strings.Contains(err.Error(), "limit must be a non-negative integer") {
+		t.Errorf("expected non-negative limit error, got %v", err)
+	}
+}
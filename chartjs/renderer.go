@@ -3,6 +3,7 @@ package chartjs
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"text/template"
@@ -10,27 +11,25 @@ import (
 	"github.com/michenriksen/chart"
 )
 
-const configTmpl = `// Chart.js configuration (https://www.chartjs.org/docs/latest/configuration/).
+const configHeader = `// Chart.js configuration (https://www.chartjs.org/docs/latest/configuration/).
 // Generated by chart (https://github.com/michenriksen/chart).
-const config = {
-  type: "bar",
-  data: {
-    datasets: [{
-      data: {{ js .Values}},
-    }],
-    labels: {{.Labels}},
-  },
-  {{- with .Title }}
-  options: {
-    plugins: {
-      title: {
-        display: true,
-        text: "{{ js . }}"
-      }
-    }
-  }
-  {{- end }}
-}
+const config = `
+
+const htmlTmpl = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>{{ html (or .Title "Chart") }}</title>
+  <script src="https://cdn.jsdelivr.net/npm/chart.js"></script>
+</head>
+<body>
+  <canvas id="chart"></canvas>
+  <script>
+{{ .Config -}}
+new Chart(document.getElementById("chart"), config);
+  </script>
+</body>
+</html>
 `
 
 // Renderer renders a [chart.Chart] as a basic configuration object for a
@@ -38,8 +37,17 @@ const config = {
 //
 // See: https://www.chartjs.org/docs/latest/charts/bar.html
 type Renderer struct {
-	tmpl  *template.Template
-	title string
+	tmpl        *template.Template
+	title       string
+	stacked     bool
+	grid        *bool
+	beginAtZero *bool
+	horizontal  bool
+	colors      []string
+	posColor    string
+	negColor    string
+	borderWidth int
+	standalone  bool
 }
 
 // NewRenderer returns a [chart.Renderer] for rendering a [chart.Chart] as a
@@ -48,7 +56,7 @@ type Renderer struct {
 // See: https://www.chartjs.org/docs/latest/charts/bar.html
 func NewRenderer(opts ...RendererOption) (*Renderer, error) {
 	r := &Renderer{
-		tmpl: template.Must(template.New("config").Parse(configTmpl)),
+		tmpl: template.Must(template.New("html").Parse(htmlTmpl)),
 	}
 
 	for i, opt := range opts {
@@ -60,39 +68,94 @@ func NewRenderer(opts ...RendererOption) (*Renderer, error) {
 	return r, nil
 }
 
+// config represents a Chart.js configuration object.
+type config struct {
+	Type    string   `json:"type"`
+	Data    data     `json:"data"`
+	Options *options `json:"options,omitempty"`
+}
+
+// data represents the data of a Chart.js configuration.
+type data struct {
+	Datasets []dataset `json:"datasets"`
+	Labels   []string  `json:"labels"`
+}
+
+// dataset represents a Chart.js dataset.
+type dataset struct {
+	Label           string    `json:"label,omitempty"`
+	Data            []float64 `json:"data"`
+	BackgroundColor any       `json:"backgroundColor,omitempty"`
+	BorderColor     any       `json:"borderColor,omitempty"`
+	BorderWidth     int       `json:"borderWidth,omitempty"`
+}
+
+// options represents the options of a Chart.js configuration.
+type options struct {
+	IndexAxis string            `json:"indexAxis,omitempty"`
+	Plugins   *plugins          `json:"plugins,omitempty"`
+	Scales    map[string]*scale `json:"scales,omitempty"`
+}
+
+// plugins represents the plugin options of a Chart.js configuration.
+type plugins struct {
+	Title *title `json:"title,omitempty"`
+}
+
+// title represents the title plugin options of a Chart.js configuration.
+type title struct {
+	Display bool   `json:"display"`
+	Text    string `json:"text"`
+}
+
+// scale represents the options for a Chart.js axis scale.
+type scale struct {
+	Stacked     bool  `json:"stacked,omitempty"`
+	BeginAtZero *bool `json:"beginAtZero,omitempty"`
+	Grid        *grid `json:"grid,omitempty"`
+}
+
+// grid represents the gridline options for a Chart.js axis scale.
+type grid struct {
+	Display bool `json:"display"`
+}
+
 // Render renders chart to out writer.
+//
+// If the chart has series values, a dataset is rendered for each series.
 func (r *Renderer) Render(c *chart.Chart, out io.Writer) (int, error) {
-	labels := c.Labels()
-	values := make([]float64, 0, len(labels))
-
-	for _, label := range labels {
-		value, err := c.Value(label)
-		if err != nil {
-			return 0, fmt.Errorf("getting value for %q label: %w", label, err)
-		}
+	labels, values := c.Snapshot()
 
-		values = append(values, value)
+	datasets, err := r.datasets(c, labels, values)
+	if err != nil {
+		return 0, err
 	}
 
-	jsonLabels, err := json.Marshal(labels)
-	if err != nil {
-		return 0, fmt.Errorf("encoding labels: %w", err)
+	r.colorize(datasets, len(labels))
+
+	cfg := config{
+		Type:    "bar",
+		Data:    data{Datasets: datasets, Labels: labels},
+		Options: r.options(len(datasets) > 1),
 	}
 
-	jsonValues, err := json.Marshal(values)
+	jsonCfg, err := json.MarshalIndent(cfg, "", "  ")
 	if err != nil {
-		return 0, fmt.Errorf("encoding values: %w", err)
+		return 0, fmt.Errorf("encoding configuration: %w", err)
 	}
 
 	buf := new(bytes.Buffer)
-	data := map[string]any{
-		"Labels": string(jsonLabels),
-		"Values": string(jsonValues),
-		"Title":  r.title,
-	}
+	buf.WriteString(configHeader)
+	buf.Write(jsonCfg)
+	buf.WriteByte('\n')
 
-	if err := r.tmpl.Execute(buf, data); err != nil {
-		return 0, fmt.Errorf("rendering configuration: %w", err)
+	if r.standalone {
+		doc := new(bytes.Buffer)
+		if err := r.tmpl.Execute(doc, map[string]any{"Title": r.title, "Config": buf.String()}); err != nil {
+			return 0, fmt.Errorf("rendering HTML document: %w", err)
+		}
+
+		buf = doc
 	}
 
 	n, err := out.Write(buf.Bytes())
@@ -103,6 +166,135 @@ func (r *Renderer) Render(c *chart.Chart, out io.Writer) (int, error) {
 	return n, nil
 }
 
+// options returns the configured chart options, or nil if no options are
+// configured.
+func (r *Renderer) options(multiple bool) *options {
+	opts := &options{Scales: r.scales(r.stacked && multiple)}
+
+	if r.horizontal {
+		opts.IndexAxis = "y"
+	}
+
+	if r.title != "" {
+		opts.Plugins = &plugins{Title: &title{Display: true, Text: r.title}}
+	}
+
+	if opts.IndexAxis == "" && opts.Plugins == nil && opts.Scales == nil {
+		return nil
+	}
+
+	return opts
+}
+
+// scales returns the configured axis scales, or nil if no scale options are
+// configured. Value axis options apply to the y-axis, or the x-axis for
+// horizontal bars.
+func (r *Renderer) scales(stacked bool) map[string]*scale {
+	index := &scale{Stacked: stacked}
+	value := &scale{Stacked: stacked, BeginAtZero: r.beginAtZero}
+
+	if r.grid != nil {
+		value.Grid = &grid{Display: *r.grid}
+	}
+
+	scales := map[string]*scale{"x": index, "y": value}
+	if r.horizontal {
+		scales["x"], scales["y"] = value, index
+	}
+
+	for axis, s := range scales {
+		if *s == (scale{}) {
+			delete(scales, axis)
+		}
+	}
+
+	if len(scales) == 0 {
+		return nil
+	}
+
+	return scales
+}
+
+// colorize sets configured colors and border width on datasets.
+//
+// With diverging colors, each bar gets a color by the sign of its value.
+// Otherwise, a single dataset gets colors cycled across its bars, while
+// multiple datasets get a color each.
+func (r *Renderer) colorize(datasets []dataset, n int) {
+	for i := range datasets {
+		datasets[i].BorderWidth = r.borderWidth
+	}
+
+	if r.posColor != "" {
+		for i := range datasets {
+			colors := make([]string, len(datasets[i].Data))
+			for j, value := range datasets[i].Data {
+				colors[j] = r.negColor
+				if value >= 0 {
+					colors[j] = r.posColor
+				}
+			}
+
+			datasets[i].BackgroundColor = colors
+
+			if r.borderWidth > 0 {
+				datasets[i].BorderColor = colors
+			}
+		}
+
+		return
+	}
+
+	if len(r.colors) == 0 {
+		return
+	}
+
+	for i := range datasets {
+		var colors any = r.colors[i%len(r.colors)]
+
+		if len(datasets) == 1 {
+			cycled := make([]string, n)
+			for j := range cycled {
+				cycled[j] = r.colors[j%len(r.colors)]
+			}
+
+			colors = cycled
+		}
+
+		datasets[i].BackgroundColor = colors
+
+		if r.borderWidth > 0 {
+			datasets[i].BorderColor = colors
+		}
+	}
+}
+
+func (*Renderer) datasets(c *chart.Chart, labels []string, values []float64) ([]dataset, error) {
+	series := c.Series()
+	if len(series) == 0 {
+		return []dataset{{Data: values}}, nil
+	}
+
+	datasets := make([]dataset, 0, len(series))
+
+	for _, name := range series {
+		values := make([]float64, 0, len(labels))
+
+		for _, label := range labels {
+			value, err := c.SeriesValue(label, name)
+			if err != nil {
+				return nil, fmt.Errorf("getting %q series value for %q label: %w", name, label, err)
+			}
+
+			values = append(values, value)
+		}
+
+		datasets = append(datasets, dataset{Label: name, Data: values})
+	}
+
+	return datasets, nil
+}
+
 // RendererOption configures a [Renderer].
 type RendererOption func(*Renderer) error
 
@@ -113,3 +305,98 @@ func WithTitle(title string) RendererOption {
 		return nil
 	}
 }
+
+// WithStacked configures a [Renderer] to stack the bars of charts with
+// multiple series. Charts without multiple series are not affected.
+func WithStacked(enable bool) RendererOption {
+	return func(r *Renderer) error {
+		r.stacked = enable
+		return nil
+	}
+}
+
+// WithGrid configures a [Renderer] to show or hide the value axis gridlines.
+// Chart.js shows gridlines by default.
+func WithGrid(enable bool) RendererOption {
+	return func(r *Renderer) error {
+		r.grid = &enable
+		return nil
+	}
+}
+
+// WithBeginAtZero configures a [Renderer] to start the value axis at zero or
+// not. By default, Chart.js starts the axis at zero only if values are close
+// to zero.
+func WithBeginAtZero(enable bool) RendererOption {
+	return func(r *Renderer) error {
+		r.beginAtZero = &enable
+		return nil
+	}
+}
+
+// WithHorizontal configures a [Renderer] to draw horizontal bars.
+//
+// Chart.js draws vertical bars by default, so unlike the other renderers of
+// this module, bars are vertical unless this option is enabled.
+func WithHorizontal(enable bool) RendererOption {
+	return func(r *Renderer) error {
+		r.horizontal = enable
+		return nil
+	}
+}
+
+// WithColors configures a [Renderer] with colors for bars. Colors can be any
+// CSS color, like #4e79a7 or rgba(78, 121, 167, 0.5), and are passed through
+// verbatim.
+//
+// For charts without series, colors are cycled across bars. For charts with
+// series, colors are cycled across datasets.
+func WithColors(colors []string) RendererOption {
+	return func(r *Renderer) error {
+		if len(colors) == 0 {
+			return errors.New("colors must not be empty")
+		}
+
+		r.colors = colors
+		return nil
+	}
+}
+
+// WithDivergingColors configures a [Renderer] to color bars with zero or
+// positive values with pos and bars with negative values with neg, e.g. for
+// profits and losses. Colors are passed through verbatim like with
+// [WithColors], which they take precedence over.
+func WithDivergingColors(pos, neg string) RendererOption {
+	return func(r *Renderer) error {
+		if pos == "" || neg == "" {
+			return errors.New("diverging colors must not be empty")
+		}
+
+		r.posColor, r.negColor = pos, neg
+		return nil
+	}
+}
+
+// WithBorderWidth configures a [Renderer] with a border width in pixels for
+// bars. Borders use the colors configured with [WithColors] or
+// [WithDivergingColors].
+func WithBorderWidth(n int) RendererOption {
+	return func(r *Renderer) error {
+		if n < 0 {
+			return errors.New("border width must not be negative")
+		}
+
+		r.borderWidth = n
+		return nil
+	}
+}
+
+// WithStandaloneHTML configures a [Renderer] to render a complete HTML
+// document that loads Chart.js from a CDN and draws the chart on a canvas,
+// instead of only the configuration object.
+func WithStandaloneHTML(enable bool) RendererOption {
+	return func(r *Renderer) error {
+		r.standalone = enable
+		return nil
+	}
+}
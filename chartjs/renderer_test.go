@@ -0,0 +1,209 @@
+package chartjs_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/michenriksen/chart"
+	"github.com/michenriksen/chart/chartjs"
+)
+
+func TestRenderEscaping(t *testing.T) {
+	label := "say \"hi\"\\\nbye"
+	title := "The \"best\"\ntitle </script>"
+
+	c, err := chart.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set(label, 42)
+
+	r, err := chartjs.NewRenderer(chartjs.WithTitle(title))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := new(bytes.Buffer)
+	if _, err := r.Render(c, buf); err != nil {
+		t.Fatal(err)
+	}
+
+	_, jsonCfg, ok := strings.Cut(buf.String(), "const config = ")
+	if !ok {
+		t.Fatalf("expected output to contain config assignment, got:\n%s", buf)
+	}
+
+	var cfg struct {
+		Data struct {
+			Labels []string `json:"labels"`
+		} `json:"data"`
+		Options struct {
+			Plugins struct {
+				Title struct {
+					Text string `json:"text"`
+				} `json:"title"`
+			} `json:"plugins"`
+		} `json:"options"`
+	}
+
+	if err := json.Unmarshal([]byte(jsonCfg), &cfg); err != nil {
+		t.Fatalf("expected config to be valid JSON: %v\n%s", err, jsonCfg)
+	}
+
+	if len(cfg.Data.Labels) != 1 || cfg.Data.Labels[0] != label {
+		t.Errorf("expected labels [%q], got %q", label, cfg.Data.Labels)
+	}
+
+	if cfg.Options.Plugins.Title.Text != title {
+		t.Errorf("expected title %q, got %q", title, cfg.Options.Plugins.Title.Text)
+	}
+}
+
+func TestRenderSeries(t *testing.T) {
+	c, err := chart.New(chart.WithPrecision(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.SetSeries("Q1", "2023", 10).
+		SetSeries("Q1", "2024", 12).
+		SetSeries("Q2", "2023", 8).
+		SetSeries("Q3", "2024", 15)
+
+	r, err := chartjs.NewRenderer()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := new(bytes.Buffer)
+	if _, err := r.Render(c, buf); err != nil {
+		t.Fatal(err)
+	}
+
+	_, jsonCfg, ok := strings.Cut(buf.String(), "const config = ")
+	if !ok {
+		t.Fatalf("expected output to contain config assignment, got:\n%s", buf)
+	}
+
+	var cfg struct {
+		Data struct {
+			Labels   []string `json:"labels"`
+			Datasets []struct {
+				Label string    `json:"label"`
+				Data  []float64 `json:"data"`
+			} `json:"datasets"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal([]byte(jsonCfg), &cfg); err != nil {
+		t.Fatalf("expected config to be valid JSON: %v\n%s", err, jsonCfg)
+	}
+
+	if got, want := cfg.Data.Labels, []string{"Q1", "Q2", "Q3"}; !slices.Equal(got, want) {
+		t.Errorf("expected labels %q, got %q", want, got)
+	}
+
+	want := []struct {
+		label string
+		data  []float64
+	}{
+		{"2023", []float64{10, 8, 0}},
+		{"2024", []float64{12, 0, 15}},
+	}
+
+	if len(cfg.Data.Datasets) != len(want) {
+		t.Fatalf("expected %d datasets, got %d", len(want), len(cfg.Data.Datasets))
+	}
+
+	for i, ds := range cfg.Data.Datasets {
+		if ds.Label != want[i].label || !slices.Equal(ds.Data, want[i].data) {
+			t.Errorf("expected dataset #%d %q %v, got %q %v", i+1, want[i].label, want[i].data, ds.Label, ds.Data)
+		}
+	}
+}
+
+func TestRenderStacked(t *testing.T) {
+	c, err := chart.New(chart.WithPrecision(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.SetSeries("Q1", "2023", 10).
+		SetSeries("Q1", "2024", 12).
+		SetSeries("Q2", "2023", 8).
+		SetSeries("Q2", "2024", 15)
+
+	r, err := chartjs.NewRenderer(chartjs.WithStacked(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := chart.RenderString(r, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `// Chart.js configuration (https://www.chartjs.org/docs/latest/configuration/).
+// Generated by chart (https://github.com/michenriksen/chart).
+const config = {
+  "type": "bar",
+  "data": {
+    "datasets": [
+      {
+        "label": "2023",
+        "data": [
+          10,
+          8
+        ]
+      },
+      {
+        "label": "2024",
+        "data": [
+          12,
+          15
+        ]
+      }
+    ],
+    "labels": [
+      "Q1",
+      "Q2"
+    ]
+  },
+  "options": {
+    "scales": {
+      "x": {
+        "stacked": true
+      },
+      "y": {
+        "stacked": true
+      }
+    }
+  }
+}
+`
+
+	if got != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, got)
+	}
+
+	// Stacking has no effect on charts with a single dataset.
+	single, err := chart.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	single.Set("Q1", 22)
+
+	got, err = chart.RenderString(r, single)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(got, "stacked") {
+		t.Errorf("expected no stacked scales for single dataset, got:\n%s", got)
+	}
+}
@@ -1,6 +1,9 @@
 package main_test
 
 import (
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
@@ -21,5 +24,42 @@ func Test(t *testing.T) {
 	testscript.Run(t, testscript.Params{
 		Dir:           "testdata/script",
 		UpdateScripts: updateGolden,
+		Setup:         setupHTTPServer,
+		Cmds: map[string]func(ts *testscript.TestScript, neg bool, args []string){
+			"gzip": cmdGzip,
+		},
 	})
 }
+
+// cmdGzip compresses a file into a new file with a .gz suffix.
+func cmdGzip(ts *testscript.TestScript, neg bool, args []string) {
+	if neg {
+		ts.Fatalf("unsupported: ! gzip")
+	}
+
+	if len(args) != 1 {
+		ts.Fatalf("usage: gzip file")
+	}
+
+	data, err := os.ReadFile(ts.MkAbs(args[0]))
+	ts.Check(err)
+
+	f, err := os.Create(ts.MkAbs(args[0] + ".gz"))
+	ts.Check(err)
+
+	w := gzip.NewWriter(f)
+	_, err = w.Write(data)
+	ts.Check(err)
+	ts.Check(w.Close())
+	ts.Check(f.Close())
+}
+
+// setupHTTPServer starts an HTTP server serving files from the script's work
+// directory and sets its URL in the HTTP_URL environment variable.
+func setupHTTPServer(env *testscript.Env) error {
+	srv := httptest.NewServer(http.FileServer(http.Dir(env.WorkDir)))
+	env.Defer(srv.Close)
+	env.Setenv("HTTP_URL", srv.URL)
+
+	return nil
+}
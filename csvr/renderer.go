@@ -0,0 +1,132 @@
+package csvr
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"unicode/utf8"
+
+	"github.com/michenriksen/chart"
+	"github.com/michenriksen/chart/internal/numfmt"
+)
+
+// Default option values.
+const (
+	DefaultComma  = ','
+	DefaultHeader = true
+)
+
+// Renderer renders a [chart.Chart] as CSV rows of labels and values.
+type Renderer struct {
+	comma    rune
+	header   bool
+	grouping bool
+	locale   numfmt.Locale
+}
+
+// NewRenderer returns a [chart.Renderer] for rendering a [chart.Chart] as CSV
+// rows of labels and values.
+func NewRenderer(opts ...RendererOption) (*Renderer, error) {
+	r := &Renderer{
+		comma:  DefaultComma,
+		header: DefaultHeader,
+		locale: numfmt.Locales[numfmt.DefaultLocale],
+	}
+
+	for i, opt := range opts {
+		if err := opt(r); err != nil {
+			return nil, fmt.Errorf("applying option #%d: %w", i+1, err)
+		}
+	}
+
+	return r, nil
+}
+
+// Render renders chart to out writer.
+func (r *Renderer) Render(c *chart.Chart, out io.Writer) (int, error) {
+	buf := new(bytes.Buffer)
+	w := csv.NewWriter(buf)
+	w.Comma = r.comma
+
+	if r.header {
+		if err := w.Write([]string{"label", "value"}); err != nil {
+			return 0, fmt.Errorf("writing header: %w", err)
+		}
+	}
+
+	loc := r.locale
+	if !r.grouping {
+		loc.Group = ""
+	}
+
+	labels, values := c.Snapshot()
+
+	for i, label := range labels {
+		value := numfmt.Format(values[i], c.Precision(), loc)
+
+		if err := w.Write([]string{label, value}); err != nil {
+			return 0, fmt.Errorf("writing row for label %q: %w", label, err)
+		}
+	}
+
+	w.Flush()
+
+	if err := w.Error(); err != nil {
+		return 0, fmt.Errorf("writing CSV: %w", err)
+	}
+
+	n, err := out.Write(buf.Bytes())
+	if err != nil {
+		return n, fmt.Errorf("writing to out: %w", err)
+	}
+
+	return n, nil
+}
+
+// RendererOption configures a [Renderer].
+type RendererOption func(*Renderer) error
+
+// WithHeader configures whether a [Renderer] writes a label,value header row.
+func WithHeader(enable bool) RendererOption {
+	return func(r *Renderer) error {
+		r.header = enable
+		return nil
+	}
+}
+
+// WithComma configures a [Renderer] with a field delimiter, e.g. '\t' for TSV.
+func WithComma(comma rune) RendererOption {
+	return func(r *Renderer) error {
+		if comma == '"' || comma == '\r' || comma == '\n' || !utf8.ValidRune(comma) || comma == utf8.RuneError {
+			return fmt.Errorf("invalid field delimiter %q", comma)
+		}
+
+		r.comma = comma
+		return nil
+	}
+}
+
+// WithGrouping configures a [Renderer] to group digits of values with
+// thousands separators, e.g. 1,234,567. Values with separators are quoted if
+// a separator is the field delimiter.
+func WithGrouping(enable bool) RendererOption {
+	return func(r *Renderer) error {
+		r.grouping = enable
+		return nil
+	}
+}
+
+// WithLocale configures a [Renderer] to format values with the decimal and
+// group separators of a locale. Supported locales are en (default), de and fr.
+func WithLocale(name string) RendererOption {
+	return func(r *Renderer) error {
+		loc, ok := numfmt.Locales[name]
+		if !ok {
+			return fmt.Errorf("unknown locale %q", name)
+		}
+
+		r.locale = loc
+		return nil
+	}
+}
@@ -0,0 +1,69 @@
+package csvr_test
+
+import (
+	"testing"
+
+	"github.com/michenriksen/chart"
+	"github.com/michenriksen/chart/csvr"
+)
+
+func TestRender(t *testing.T) {
+	c, err := chart.New(chart.WithPrecision(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set("plain", 1).Set("a, b", 2.5).Set(`say "hi"`, 1234)
+
+	tests := []struct {
+		name string
+		opts []csvr.RendererOption
+		want string
+	}{
+		{
+			name: "defaults",
+			want: "label,value\nplain,1.0\n\"a, b\",2.5\n\"say \"\"hi\"\"\",1234.0\n",
+		},
+		{
+			name: "tab delimiter",
+			opts: []csvr.RendererOption{csvr.WithComma('\t')},
+			want: "label\tvalue\nplain\t1.0\na, b\t2.5\n\"say \"\"hi\"\"\"\t1234.0\n",
+		},
+		{
+			name: "no header",
+			opts: []csvr.RendererOption{csvr.WithHeader(false)},
+			want: "plain,1.0\n\"a, b\",2.5\n\"say \"\"hi\"\"\",1234.0\n",
+		},
+		{
+			name: "grouping",
+			opts: []csvr.RendererOption{csvr.WithHeader(false), csvr.WithGrouping(true)},
+			want: "plain,1.0\n\"a, b\",2.5\n\"say \"\"hi\"\"\",\"1,234.0\"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := csvr.NewRenderer(tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := chart.RenderString(r, c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.want {
+				t.Errorf("expected:\n%q\ngot:\n%q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWithCommaInvalid(t *testing.T) {
+	for _, comma := range []rune{'"', '\r', '\n'} {
+		if _, err := csvr.NewRenderer(csvr.WithComma(comma)); err == nil {
+			t.Errorf("expected error for field delimiter %q", comma)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package chart
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+)
+
+// chartState is the serializable state of a [Chart].
+type chartState struct {
+	Labels    []string      `json:"labels"`
+	Values    []float64     `json:"values"`
+	Series    []seriesState `json:"series,omitempty"`
+	Sort      SortOption    `json:"sort"`
+	SortDir   SortDirection `json:"sortDirection"`
+	Reverse   bool          `json:"reverse,omitempty"`
+	Precision int           `json:"precision"`
+}
+
+// seriesState is the serializable state of a chart series.
+type seriesState struct {
+	Name   string    `json:"name"`
+	Labels []string  `json:"labels"`
+	Values []float64 `json:"values"`
+}
+
+// state returns the serializable state of the chart. Labels are in order of
+// insertion and values are not rounded.
+func (c *Chart) state() chartState {
+	st := chartState{
+		Labels:    c.data.keys(),
+		Sort:      c.sort,
+		SortDir:   c.sortDir,
+		Reverse:   c.reverse,
+		Precision: c.prec,
+	}
+
+	st.Values = make([]float64, 0, len(st.Labels))
+	for _, label := range st.Labels {
+		val, _ := c.data.get(label)
+		st.Values = append(st.Values, val)
+	}
+
+	for _, name := range c.series.names() {
+		data := c.series.data(name)
+		ss := seriesState{Name: name, Labels: data.keys()}
+
+		for _, label := range ss.Labels {
+			val, _ := data.get(label)
+			ss.Values = append(ss.Values, val)
+		}
+
+		st.Series = append(st.Series, ss)
+	}
+
+	return st
+}
+
+// setState replaces the chart's data and options with a serialized state.
+// Returns an error if the state is invalid.
+func (c *Chart) setState(st chartState) error {
+	if len(st.Labels) != len(st.Values) {
+		return fmt.Errorf("%d labels don't match %d values", len(st.Labels), len(st.Values))
+	}
+
+	restored, err := New(
+		WithSorting(st.Sort, st.SortDir),
+		WithReversed(st.Reverse),
+		WithPrecision(st.Precision),
+	)
+	if err != nil {
+		return err
+	}
+
+	for i, label := range st.Labels {
+		restored.Set(label, st.Values[i])
+	}
+
+	for _, ss := range st.Series {
+		if len(ss.Labels) != len(ss.Values) {
+			return fmt.Errorf("series %q: %d labels don't match %d values", ss.Name, len(ss.Labels), len(ss.Values))
+		}
+
+		data := restored.series.data(ss.Name)
+		for i, label := range ss.Labels {
+			data.set(label, ss.Values[i])
+		}
+	}
+
+	*c = *restored
+
+	return nil
+}
+
+// MarshalJSON implements [json.Marshaler].
+//
+// Labels are encoded in order of insertion along with their unrounded values,
+// series, and sorting and precision options, so the chart can be restored with
+// [Chart.UnmarshalJSON].
+func (c *Chart) MarshalJSON() ([]byte, error) {
+	b, err := json.Marshal(c.state())
+	if err != nil {
+		return nil, fmt.Errorf("encoding chart: %w", err)
+	}
+
+	return b, nil
+}
+
+// UnmarshalJSON implements [json.Unmarshaler].
+//
+// The chart's data and options are replaced with those of a chart encoded by
+// [Chart.MarshalJSON].
+func (c *Chart) UnmarshalJSON(b []byte) error {
+	var st chartState
+	if err := json.Unmarshal(b, &st); err != nil {
+		return fmt.Errorf("decoding chart: %w", err)
+	}
+
+	if err := c.setState(st); err != nil {
+		return fmt.Errorf("decoding chart: %w", err)
+	}
+
+	return nil
+}
+
+// GobEncode implements [gob.GobEncoder].
+//
+// Like [Chart.MarshalJSON], labels are encoded in order of insertion along with
+// their unrounded values, series, and sorting and precision options.
+func (c *Chart) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+
+	if err := gob.NewEncoder(&buf).Encode(c.state()); err != nil {
+		return nil, fmt.Errorf("encoding chart: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements [gob.GobDecoder].
+//
+// The chart's data and options are replaced with those of a chart encoded by
+// [Chart.GobEncode].
+func (c *Chart) GobDecode(b []byte) error {
+	var st chartState
+	if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&st); err != nil {
+		return fmt.Errorf("decoding chart: %w", err)
+	}
+
+	if err := c.setState(st); err != nil {
+		return fmt.Errorf("decoding chart: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package chart
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Equal reports whether the chart and other have the same labels in the same
+// order, the same values and series values within the chart precision, and
+// the same options. Use [Chart.Diff] to describe the differences.
+func (c *Chart) Equal(other *Chart) bool {
+	return c.Diff(other) == ""
+}
+
+// Diff returns a human-readable description of the differences between the
+// chart and other, with one difference per line, or an empty string if the
+// charts are equal according to [Chart.Equal].
+//
+// Labels are compared in the order they are sorted and ordered, and values
+// are compared rounded to the chart precision.
+func (c *Chart) Diff(other *Chart) string {
+	switch {
+	case c == nil && other == nil:
+		return ""
+	case c == nil:
+		return "chart is nil"
+	case other == nil:
+		return "other chart is nil"
+	}
+
+	var diffs []string
+
+	diff := func(format string, args ...any) {
+		diffs = append(diffs, fmt.Sprintf(format, args...))
+	}
+
+	if c.sort != other.sort {
+		diff("sort option: %d != %d", c.sort, other.sort)
+	}
+
+	if c.sortDir != other.sortDir {
+		diff("sort direction: %d != %d", c.sortDir, other.sortDir)
+	}
+
+	if c.reverse != other.reverse {
+		diff("reversed: %t != %t", c.reverse, other.reverse)
+	}
+
+	if c.prec != other.prec {
+		diff("precision: %d != %d", c.prec, other.prec)
+	}
+
+	labels, values := c.Snapshot()
+	otherLabels, otherValues := other.Snapshot()
+
+	if !slices.Equal(labels, otherLabels) {
+		diff("labels: %q != %q", labels, otherLabels)
+	} else {
+		for i, label := range labels {
+			if values[i] != otherValues[i] {
+				diff("value of %q: %v != %v", label, values[i], otherValues[i])
+			}
+		}
+	}
+
+	series, otherSeries := c.Series(), other.Series()
+
+	if !slices.Equal(series, otherSeries) {
+		diff("series: %q != %q", series, otherSeries)
+	} else {
+		for _, name := range series {
+			for _, label := range labels {
+				val, _ := c.series.get(name, label)
+				otherVal, _ := other.series.get(name, label)
+
+				if c.round(val) != other.round(otherVal) {
+					diff("%q series value of %q: %v != %v", name, label, c.round(val), other.round(otherVal))
+				}
+			}
+		}
+	}
+
+	return strings.Join(diffs, "\n")
+}
@@ -0,0 +1,126 @@
+package gnuplot
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/michenriksen/chart"
+)
+
+// barWidth is the height of horizontal bars relative to the distance between
+// them.
+const barWidth = 0.8
+
+// quoteReplacer escapes strings for use in double-quoted Gnuplot strings.
+var quoteReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// dataReplacer sanitizes labels for use in quoted inline data, where quotes
+// and newlines can't be escaped.
+var dataReplacer = strings.NewReplacer(`"`, `'`, "\n", " ", "\r", " ")
+
+// Renderer renders a [chart.Chart] as a Gnuplot script with inline data for a
+// horizontal bar chart.
+//
+// The script can be piped directly to Gnuplot 5 or later:
+//
+//	chart --format gnuplot < data.txt | gnuplot -persist
+type Renderer struct {
+	title    string
+	terminal string
+	output   string
+}
+
+// NewRenderer returns a [chart.Renderer] for rendering a [chart.Chart] as a
+// Gnuplot script.
+func NewRenderer(opts ...RendererOption) (*Renderer, error) {
+	r := &Renderer{}
+
+	for i, opt := range opts {
+		if err := opt(r); err != nil {
+			return nil, fmt.Errorf("applying option #%d: %w", i+1, err)
+		}
+	}
+
+	return r, nil
+}
+
+// Render renders chart to out writer.
+//
+// Bars are drawn from top to bottom in the order of the chart's labels.
+func (r *Renderer) Render(c *chart.Chart, out io.Writer) (int, error) {
+	labels, values := c.Snapshot()
+	buf := new(bytes.Buffer)
+
+	fmt.Fprintln(buf, "# Gnuplot script (http://www.gnuplot.info/).")
+	fmt.Fprintln(buf, "# Generated by chart (https://github.com/michenriksen/chart).")
+
+	if r.terminal != "" {
+		fmt.Fprintf(buf, "set terminal %s\n", r.terminal)
+	}
+
+	if r.output != "" {
+		fmt.Fprintf(buf, "set output %s\n", quote(r.output))
+	}
+
+	if r.title != "" {
+		fmt.Fprintf(buf, "set title %s\n", quote(r.title))
+	}
+
+	fmt.Fprintln(buf, "set style fill solid")
+	fmt.Fprintln(buf, "unset key")
+	fmt.Fprintf(buf, "set yrange [-0.5:%g]\n", float64(max(len(labels), 1))-0.5)
+	fmt.Fprintln(buf, "set xrange [0:*]")
+	fmt.Fprintln(buf, "$data << EOD")
+
+	// Labels are numbered from the bottom, so the first label is at the top.
+	for i, label := range labels {
+		fmt.Fprintf(buf, "%d \"%s\" %s\n",
+			len(labels)-1-i, dataReplacer.Replace(label), strconv.FormatFloat(values[i], 'f', c.Precision(), 64))
+	}
+
+	fmt.Fprintln(buf, "EOD")
+	fmt.Fprintf(buf, "plot $data using ($3/2):1:($3/2):(%g):ytic(2) with boxxyerror\n", barWidth/2)
+
+	n, err := out.Write(buf.Bytes())
+	if err != nil {
+		return n, fmt.Errorf("writing to out: %w", err)
+	}
+
+	return n, nil
+}
+
+func quote(s string) string {
+	return `"` + quoteReplacer.Replace(s) + `"`
+}
+
+// RendererOption configures a [Renderer].
+type RendererOption func(*Renderer) error
+
+// WithTitle configures a [Renderer] with a chart title.
+func WithTitle(title string) RendererOption {
+	return func(r *Renderer) error {
+		r.title = title
+		return nil
+	}
+}
+
+// WithTerminal configures a [Renderer] with a Gnuplot terminal, e.g. pngcairo
+// or svg size 800,600. The terminal is passed through verbatim.
+func WithTerminal(terminal string) RendererOption {
+	return func(r *Renderer) error {
+		r.terminal = terminal
+		return nil
+	}
+}
+
+// WithOutput configures a [Renderer] with a file for Gnuplot to write the
+// chart to. Use with [WithTerminal] for file-based terminals.
+func WithOutput(file string) RendererOption {
+	return func(r *Renderer) error {
+		r.output = file
+		return nil
+	}
+}
@@ -2,9 +2,13 @@ module github.com/michenriksen/chart
 
 go 1.22.4
 
-require github.com/rogpeppe/go-internal v1.12.0
+require (
+	github.com/rogpeppe/go-internal v1.12.0
+	golang.org/x/term v0.22.0
+	golang.org/x/text v0.16.0
+)
 
 require (
-	golang.org/x/sys v0.0.0-20220722155257-8c9f86f7a55f // indirect
-	golang.org/x/tools v0.1.12 // indirect
+	golang.org/x/sys v0.22.0 // indirect
+	golang.org/x/tools v0.21.1-0.20240508182429-e35e4ccd0d2d // indirect
 )
@@ -0,0 +1,163 @@
+package html
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/michenriksen/chart"
+)
+
+const docTmpl = `
+{{- define "bars" -}}
+<div class="{{ .Prefix }}chart">
+{{- with .Title }}
+  <div class="{{ $.Prefix }}title"{{ if $.InlineStyles }} style="font-weight: bold; margin-bottom: 8px"{{ end }}>{{ . }}</div>
+{{- end }}
+{{- range .Bars }}
+  <div class="{{ $.Prefix }}bar" style="width: {{ .Width }}%
+    {{- if $.InlineStyles }}; background: #4e79a7; color: #fff; margin: 2px 0; padding: 2px 4px; white-space: nowrap{{ end }}">
+    <span class="{{ $.Prefix }}label">{{ .Label }}</span>
+    <span class="{{ $.Prefix }}value">{{ .Value }}</span>
+  </div>
+{{- end }}
+</div>
+{{ end -}}
+{{- if .Standalone -}}
+<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>{{ or .Title "Chart" }}</title>
+{{- if not .InlineStyles }}
+  <style>
+    .{{ .Prefix }}chart { font-family: sans-serif; }
+    .{{ .Prefix }}title { font-weight: bold; margin-bottom: 8px; }
+    .{{ .Prefix }}bar { background: #4e79a7; color: #fff; margin: 2px 0; padding: 2px 4px; white-space: nowrap; }
+  </style>
+{{- end }}
+</head>
+<body>
+{{ template "bars" . -}}
+</body>
+</html>
+{{ else -}}
+{{ template "bars" . -}}
+{{ end -}}
+`
+
+// Renderer renders a [chart.Chart] as an HTML bar chart.
+type Renderer struct {
+	tmpl         *template.Template
+	title        string
+	prefix       string
+	inlineStyles bool
+	standalone   bool
+}
+
+type bar struct {
+	Label string
+	Value string
+	Width float64
+}
+
+// NewRenderer returns a [chart.Renderer] for rendering a [chart.Chart] as an
+// HTML bar chart.
+//
+// By default, the chart is rendered as an HTML fragment without styling. Bars
+// are sized with inline width styles relative to the highest chart value.
+func NewRenderer(opts ...RendererOption) (*Renderer, error) {
+	r := &Renderer{
+		tmpl: template.Must(template.New("chart").Parse(docTmpl)),
+	}
+
+	for i, opt := range opts {
+		if err := opt(r); err != nil {
+			return nil, fmt.Errorf("applying option #%d: %w", i+1, err)
+		}
+	}
+
+	return r, nil
+}
+
+// Render renders chart to out writer.
+func (r *Renderer) Render(c *chart.Chart, out io.Writer) (int, error) {
+	labels, values := c.Snapshot()
+	bars := make([]bar, 0, len(labels))
+
+	maxVal := 0.0
+	for _, value := range values {
+		maxVal = max(maxVal, value)
+	}
+
+	for i, label := range labels {
+		value := values[i]
+
+		width := 0.0
+		if maxVal > 0 {
+			width = math.Round(max(value, 0)/maxVal*100*100) / 100
+		}
+
+		bars = append(bars, bar{Label: label, Value: strconv.FormatFloat(value, 'f', c.Precision(), 64), Width: width})
+	}
+
+	buf := new(bytes.Buffer)
+	data := map[string]any{
+		"Bars":         bars,
+		"Title":        r.title,
+		"Prefix":       r.prefix,
+		"InlineStyles": r.inlineStyles,
+		"Standalone":   r.standalone,
+	}
+
+	if err := r.tmpl.Execute(buf, data); err != nil {
+		return 0, fmt.Errorf("rendering HTML: %w", err)
+	}
+
+	n, err := out.Write(buf.Bytes())
+	if err != nil {
+		return n, fmt.Errorf("writing to out: %w", err)
+	}
+
+	return n, nil
+}
+
+// RendererOption configures a [Renderer].
+type RendererOption func(*Renderer) error
+
+// WithTitle configures a [Renderer] with a chart title.
+func WithTitle(title string) RendererOption {
+	return func(r *Renderer) error {
+		r.title = title
+		return nil
+	}
+}
+
+// WithClassPrefix configures a [Renderer] with a prefix for CSS class names.
+func WithClassPrefix(prefix string) RendererOption {
+	return func(r *Renderer) error {
+		r.prefix = prefix
+		return nil
+	}
+}
+
+// WithInlineStyles configures a [Renderer] to style chart elements with inline
+// styles, making the chart display properly without an external stylesheet.
+func WithInlineStyles(enable bool) RendererOption {
+	return func(r *Renderer) error {
+		r.inlineStyles = enable
+		return nil
+	}
+}
+
+// WithStandalone configures a [Renderer] to render a complete HTML document
+// instead of an HTML fragment.
+func WithStandalone(enable bool) RendererOption {
+	return func(r *Renderer) error {
+		r.standalone = enable
+		return nil
+	}
+}
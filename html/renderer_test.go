@@ -0,0 +1,133 @@
+package html_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/michenriksen/chart"
+	"github.com/michenriksen/chart/html"
+)
+
+func TestRender(t *testing.T) {
+	c, err := chart.New(chart.WithPrecision(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set("a <b>", 2).Set("c", 4)
+
+	r, err := html.NewRenderer()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := chart.RenderString(r, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `<div class="chart">
+  <div class="bar" style="width: 50%">
+    <span class="label">a &lt;b&gt;</span>
+    <span class="value">2</span>
+  </div>
+  <div class="bar" style="width: 100%">
+    <span class="label">c</span>
+    <span class="value">4</span>
+  </div>
+</div>
+`
+
+	if got != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestRenderOptions(t *testing.T) {
+	c, err := chart.New(chart.WithPrecision(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set("a", 2)
+
+	tests := []struct {
+		name    string
+		opts    []html.RendererOption
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "title",
+			opts:    []html.RendererOption{html.WithTitle("Sales <2024>")},
+			want:    []string{`<div class="title">Sales &lt;2024&gt;</div>`},
+			notWant: []string{"<!DOCTYPE html>", "style=\"font-weight"},
+		},
+		{
+			name: "class prefix",
+			opts: []html.RendererOption{html.WithTitle("T"), html.WithClassPrefix("x-")},
+			want: []string{
+				`<div class="x-chart">`,
+				`<div class="x-title">T</div>`,
+				`<div class="x-bar" style="width: 100%">`,
+				`<span class="x-label">a</span>`,
+				`<span class="x-value">2</span>`,
+			},
+		},
+		{
+			name: "inline styles",
+			opts: []html.RendererOption{html.WithTitle("T"), html.WithInlineStyles(true)},
+			want: []string{
+				`<div class="title" style="font-weight: bold; margin-bottom: 8px">T</div>`,
+				`<div class="bar" style="width: 100%; background: #4e79a7;`,
+			},
+		},
+		{
+			name: "standalone",
+			opts: []html.RendererOption{html.WithStandalone(true), html.WithClassPrefix("x-")},
+			want: []string{
+				"<!DOCTYPE html>",
+				"<title>Chart</title>",
+				".x-bar { background: #4e79a7;",
+				"<body>\n<div class=\"x-chart\">",
+				"</body>\n</html>\n",
+			},
+		},
+		{
+			name: "standalone with title and inline styles",
+			opts: []html.RendererOption{
+				html.WithStandalone(true),
+				html.WithTitle("T"),
+				html.WithInlineStyles(true),
+			},
+			want:    []string{"<!DOCTYPE html>", "<title>T</title>"},
+			notWant: []string{"<style>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := html.NewRenderer(tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := chart.RenderString(r, c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, s := range tt.want {
+				if !strings.Contains(got, s) {
+					t.Errorf("expected output to contain %q, got:\n%s", s, got)
+				}
+			}
+
+			for _, s := range tt.notWant {
+				if strings.Contains(got, s) {
+					t.Errorf("expected output not to contain %q, got:\n%s", s, got)
+				}
+			}
+		})
+	}
+}
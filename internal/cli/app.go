@@ -1,19 +1,16 @@
 package cli
 
 import (
-	"bufio"
+	"context"
 	"errors"
 	"flag"
 	"fmt"
 	"log/slog"
 	"os"
-	"strings"
+	"os/signal"
 	"time"
 
 	"github.com/michenriksen/chart"
-	"github.com/michenriksen/chart/chartjs"
-	"github.com/michenriksen/chart/mermaid"
-	"github.com/michenriksen/chart/simple"
 )
 
 const (
@@ -46,78 +43,138 @@ func Run() int {
 		return exitNormal
 	}
 
-	c, err := chart.New(
-		chart.WithSorting(flags.Sort(), flags.SortDirection()),
-		chart.WithPrecision(flags.Precision),
-	)
+	renderer, err := renderers[flags.Format](flags)
 	if err != nil {
-		return fatal("creating chart", err)
+		return fatal("creating renderer", err)
 	}
 
-	in, err := flags.In()
+	out, err := flags.Out()
 	if err != nil {
-		return fatal("opening input", err)
+		return fatal("opening output", err)
 	}
+	defer out.Close()
 
-	scanner := bufio.NewScanner(in)
+	if flags.Watch {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
 
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" || strings.HasPrefix(line, "#") {
-			continue
-		}
+		return watch(ctx, flags, renderer, out)
+	}
 
-		if flags.Count {
-			c.Add(strings.TrimSpace(scanner.Text()), 1)
-			continue
-		}
+	if flags.Stream {
+		return stream(flags, renderer, out)
+	}
 
-		value, label, err := chart.ParseLine(line)
-		if err != nil {
-			slog.Warn("skipping unparsable line", "error", err, "line", line)
-			continue
-		}
+	c, err := buildChart(flags)
+	if err != nil {
+		return fatal("building chart", err)
+	}
 
-		c.Set(label, value)
+	if _, err := renderer.Render(c, out); err != nil {
+		return fatal("rendering chart", err)
 	}
 
-	in.Close()
+	return exitNormal
+}
 
-	var renderer chart.Renderer
+// buildChart reads and parses input into a chart configured from flags.
+func buildChart(flags *flags) (*chart.Chart, error) {
+	c, err := chart.New(chartOptions(flags)...)
+	if err != nil {
+		return nil, fmt.Errorf("creating chart: %w", err)
+	}
+
+	in, err := flags.In()
+	if err != nil {
+		return nil, fmt.Errorf("opening input: %w", err)
+	}
 
 	switch {
-	case flags.Mermaid:
-		renderer, err = mermaid.NewRenderer(
-			mermaid.WithTitle(flags.Title),
-		)
-	case flags.Chartjs:
-		renderer, err = chartjs.NewRenderer(
-			chartjs.WithTitle(flags.Title),
-		)
+	case flags.InFormat() == "json":
+		err = chart.ParseJSON(in, c)
+	case flags.InFormat() == "csv":
+		err = chart.ParseCSV(in, c, flags.CSVOptions()...)
+	case flags.Histogram > 0:
+		var values []float64
+
+		if values, err = chart.ParseValues(in, parseOptions(flags)...); err == nil {
+			// Scale values before binning, so bins are in the scaled unit.
+			for i := range values {
+				values[i] *= flags.ScaleBy
+			}
+
+			c, err = chart.Histogram(values, flags.Histogram, chartOptions(flags)...)
+		}
 	default:
-		renderer, err = simple.NewRenderer(
-			simple.WithMaxLength(flags.MaxLength),
-			simple.WithMaxLabelLength(flags.MaxLabelLength),
-			simple.WithScaling(flags.Scale),
-			simple.WithTick(flags.Tick()),
-		)
+		err = chart.Parse(in, c, parseOptions(flags)...)
 	}
 
+	in.Close()
+
 	if err != nil {
-		return fatal("creating renderer", err)
+		return nil, fmt.Errorf("parsing input: %w", err)
 	}
 
-	out, err := flags.Out()
-	if err != nil {
-		return fatal("opening output", err)
+	return reduce(flags, c), nil
+}
+
+// chartOptions returns chart options configured from flags.
+func chartOptions(flags *flags) []chart.ChartOption {
+	return []chart.ChartOption{
+		chart.WithSorting(flags.Sort(), flags.SortDirection()),
+		chart.WithReversed(flags.Reverse()),
+		chart.WithPrecision(flags.ChartPrecision()),
 	}
-	defer out.Close()
+}
 
-	if _, err := renderer.Render(c, out); err != nil {
-		return fatal("rendering chart", err)
+// parseOptions returns options for parsing data lines configured from flags.
+func parseOptions(flags *flags) []chart.ParseOption {
+	opts := []chart.ParseOption{
+		chart.WithLimit(flags.Limit),
+		chart.WithCounting(flags.Count),
+		chart.WithSumming(flags.Sum),
+		chart.WithStrict(flags.Strict),
+		chart.WithCommentPrefix(flags.Comment),
+		chart.WithWarningFunc(func(err *chart.LineError) {
+			slog.Warn("skipping unparsable line", "error", err.Err, "line", err.Line, "line_number", err.Number)
+		}),
 	}
 
-	return exitNormal
+	if sep := flags.Separator(); sep != 0 {
+		opts = append(opts, chart.WithSeparator(sep))
+	}
+
+	if filter := flags.Filter(); filter != nil {
+		opts = append(opts, chart.WithFilter(filter))
+	}
+
+	return opts
+}
+
+// reduce returns a chart with only the labels to display according to flags.
+// Values are scaled first, unless they were scaled before binning into a
+// histogram. Labels with values below the minimum value are then dropped
+// before selecting the labels with the highest values. Returns c as-is if not
+// configured.
+func reduce(flags *flags, c *chart.Chart) *chart.Chart {
+	if flags.ScaleBy != 1 && flags.Histogram <= 0 {
+		c = c.Scale(flags.ScaleBy)
+	}
+
+	if flags.minValueSet {
+		c = c.Filter(func(_ string, value float64) bool {
+			return value >= flags.MinValue
+		})
+	}
+
+	switch {
+	case flags.Top <= 0:
+		return c
+	case flags.OtherLabel != "":
+		return c.TopNWithOther(flags.Top, flags.OtherLabel)
+	default:
+		return c.TopN(flags.Top)
+	}
 }
 
 func initLogger() {
@@ -0,0 +1,49 @@
+package cli_test
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/michenriksen/chart"
+	"github.com/michenriksen/chart/internal/cli"
+)
+
+type fakeRenderer struct{}
+
+func (fakeRenderer) Render(c *chart.Chart, w io.Writer) (int, error) {
+	return fmt.Fprintf(w, "fake chart with %d labels: %v\n", c.Len(), c.Labels())
+}
+
+func TestRunRegisteredRenderer(t *testing.T) {
+	t.Cleanup(cli.RegisterRenderer("fake", fakeRenderer{}))
+
+	dir := t.TempDir()
+	in := filepath.Join(dir, "input.txt")
+	out := filepath.Join(dir, "output.txt")
+
+	if err := os.WriteFile(in, []byte("10 a\n20 b\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	args := os.Args
+	t.Cleanup(func() { os.Args = args })
+
+	os.Args = []string{"chart", "--in", in, "--out", out, "--format", "fake"}
+
+	if code := cli.Run(); code != 0 {
+		t.Fatalf("expected exit code 0, got %d", code)
+	}
+
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "fake chart with 2 labels: [a b]\n"
+	if string(got) != want {
+		t.Errorf("expected output %q, got %q", want, got)
+	}
+}
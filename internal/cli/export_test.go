@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"context"
+	"io"
+
+	"github.com/michenriksen/chart"
+)
+
+// RegisterRenderer registers r as the renderer for an output format and
+// returns a function to unregister it.
+func RegisterRenderer(format string, r chart.Renderer) func() {
+	registerRenderer(format, func(*flags) (chart.Renderer, error) {
+		return r, nil
+	})
+
+	return func() { delete(renderers, format) }
+}
+
+// Watch parses args and renders charts to out in watch mode until ctx is done.
+func Watch(ctx context.Context, args []string, out io.Writer) (int, error) {
+	flags, err := parseFlags(args)
+	if err != nil {
+		return exitError, err
+	}
+
+	renderer, err := renderers[flags.Format](flags)
+	if err != nil {
+		return exitError, err
+	}
+
+	return watch(ctx, flags, renderer, out), nil
+}
+
+// Stream parses args and renders charts to out in stream mode until input is
+// exhausted.
+func Stream(args []string, out io.Writer) (int, error) {
+	flags, err := parseFlags(args)
+	if err != nil {
+		return exitError, err
+	}
+
+	renderer, err := renderers[flags.Format](flags)
+	if err != nil {
+		return exitError, err
+	}
+
+	return stream(flags, renderer, out), nil
+}
@@ -1,16 +1,27 @@
 package cli
 
 import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
 	_ "embed"
 	"errors"
 	"flag"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/michenriksen/chart"
+	"github.com/michenriksen/chart/internal/numfmt"
 	"github.com/michenriksen/chart/simple"
+	"golang.org/x/term"
 )
 
 const (
@@ -18,34 +29,91 @@ const (
 	defaultMaxLabelLength = 20
 	defaultPrecision      = 2
 	defaultSort           = "none"
+	defaultInFormat       = "lines"
+	defaultFormat         = "simple"
+	termWidthMargin       = 2
+	httpTimeout           = 30 * time.Second
+	defaultInterval       = time.Second
 )
 
 //go:embed usage.txt
 var usage string
 
 var sortOptMap = map[string]chart.SortOption{
-	"none":     chart.SortNone,
-	"label":    chart.SortByLabel,
-	"labelnum": chart.SortByLabelNumeric,
-	"value":    chart.SortByValue,
+	"none":       chart.SortNone,
+	"label":      chart.SortByLabel,
+	"labelnum":   chart.SortByLabelNumeric,
+	"natural":    chart.SortByLabelNatural,
+	"value":      chart.SortByValue,
+	"valuelabel": chart.SortByValueThenLabel,
 }
 
+var labelAlignMap = map[string]simple.Align{
+	"right": simple.AlignRight,
+	"left":  simple.AlignLeft,
+}
+
+var inFormats = []string{"lines", "json", "csv"}
+
 // flags represents the CLI flags.
 type flags struct {
 	Count          bool   // Count occurrences of lines.
+	Sum            bool   // Sum values of repeated labels.
+	Strict         bool   // Fail on the first unparsable line.
+	Comment        string // Prefix of comment lines.
+	Histogram      int    // Number of histogram bins.
+	Limit          int    // Maximum number of parsed lines.
 	MaxLength      int    // Maximum chart length.
 	MaxLabelLength int    // Maximum label length.
 	Precision      int    // Value precision.
 	Scale          bool   // Scale bars logarithmically.
-	Mermaid        bool   // Create Mermaid XYChart.
-	Chartjs        bool   // Create Chart.js configuration.
-	Version        bool   // Display version information.
-	Title          string // Mermaid chart title.
-	in             string
+	Top            int    // Only keep labels with the highest values.
+	OtherLabel     string // Label for bucket of values not in top labels.
+	Format         string // Output format.
+	Mermaid        bool   // Create Mermaid XYChart (deprecated: use Format).
+	Chartjs        bool   // Create Chart.js configuration (deprecated: use Format).
+	JSON           bool   // Create JSON data (deprecated: use Format).
+	Sparkline      bool   // Create sparkline.
+	NoGrid         bool   // Hide Chart.js gridlines.
+	BeginAtZero    bool   // Start Chart.js value axis at zero.
+	Horizontal     bool   // Draw horizontal Chart.js bars.
+	Standalone     bool   // Create complete HTML document.
+	colors         string
+	diverging      string
+	Version        bool          // Display version information.
+	Title          string        // Mermaid chart title.
+	Unit           string        // Unit suffix for values.
+	Percentages    bool          // Display percentages of total.
+	Axis           bool          // Display axis above bars.
+	Footer         bool          // Display footer with total below bars.
+	Vertical       bool          // Draw bars as vertical columns.
+	ASCII          bool          // Draw bars with ASCII characters only.
+	NoSmallTick    bool          // Draw nothing for bars that round to zero length.
+	Watch          bool          // Re-render chart when input files change.
+	Stream         bool          // Redraw chart while input is read.
+	Interval       time.Duration // Interval for checking input files or redrawing.
+	MinValue       float64       // Drop labels with values below minimum.
+	ScaleBy        float64       // Factor to multiply values by.
+	Group          bool          // Group digits of values with thousands separators.
+	Locale         string        // Locale for formatting values.
+	in             []string
+	inFormat       string
+	csvComma       string
+	csvHeader      bool
+	separator      string
 	out            string
 	sort           string
 	desc           bool
+	reverse        bool
 	tick           string
+	include        string
+	exclude        string
+	includeRE      *regexp.Regexp
+	excludeRE      *regexp.Regexp
+	labelAlign     string
+	maxLengthSet   bool
+	minValueSet    bool
+	precisionSet   bool
 }
 
 // Sort returns the sort option to use.
@@ -62,6 +130,110 @@ func (f *flags) SortDirection() chart.SortDirection {
 	return chart.OrderAsc
 }
 
+// ChartPrecision returns the precision for values.
+//
+// If the --precision flag is not set and line occurrences or histogram values
+// are counted, values are whole numbers, so a precision of zero is used.
+func (f *flags) ChartPrecision() int {
+	if !f.precisionSet && (f.Count || f.Histogram > 0) {
+		return 0
+	}
+
+	return f.Precision
+}
+
+// Reverse returns true if the order of labels should be reversed.
+func (f *flags) Reverse() bool {
+	return f.reverse
+}
+
+// LabelAlign returns the label alignment to use.
+func (f *flags) LabelAlign() simple.Align {
+	return labelAlignMap[f.labelAlign]
+}
+
+// Filter returns a function reporting whether a label should be charted, or
+// nil if no include or exclude patterns are configured. Labels must match the
+// include pattern, if any, and exclude takes precedence over include.
+func (f *flags) Filter() func(string, float64) bool {
+	if f.includeRE == nil && f.excludeRE == nil {
+		return nil
+	}
+
+	return func(label string, _ float64) bool {
+		if f.excludeRE != nil && f.excludeRE.MatchString(label) {
+			return false
+		}
+
+		return f.includeRE == nil || f.includeRE.MatchString(label)
+	}
+}
+
+// ScaleMode returns the scale for bar lengths.
+func (f *flags) ScaleMode() simple.ScaleMode {
+	if f.Scale {
+		return simple.ScaleLog
+	}
+
+	return simple.ScaleLinear
+}
+
+// Orientation returns the direction to draw bars in.
+func (f *flags) Orientation() simple.Orientation {
+	if f.Vertical {
+		return simple.Vertical
+	}
+
+	return simple.Horizontal
+}
+
+// Colors returns the configured bar colors, or nil if none are configured.
+func (f *flags) Colors() []string {
+	if f.colors == "" {
+		return nil
+	}
+
+	return splitColors(f.colors)
+}
+
+// DivergingColors returns the configured colors for positive and negative
+// values, or empty strings if none are configured.
+func (f *flags) DivergingColors() (pos, neg string) {
+	if f.diverging == "" {
+		return "", ""
+	}
+
+	colors := splitColors(f.diverging)
+
+	return colors[0], colors[1]
+}
+
+// splitColors splits a comma-separated list of colors on commas outside
+// parentheses to keep colors like rgba(0, 0, 0, 1) intact.
+func splitColors(s string) []string {
+	var (
+		colors []string
+		depth  int
+		start  int
+	)
+
+	for i, r := range s {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				colors = append(colors, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+
+	return append(colors, strings.TrimSpace(s[start:]))
+}
+
 // Tick returns the tick to use for drawing bars.
 func (f *flags) Tick() rune {
 	if f.tick == "" {
@@ -71,19 +243,212 @@ func (f *flags) Tick() rune {
 	return rune(f.tick[0])
 }
 
-// In returns the reader to read data from.
+// ChartLength returns the maximum chart length to use.
+//
+// If the --length flag is not set and output is written to a terminal, the
+// terminal width minus a small margin is used.
+func (f *flags) ChartLength() int {
+	if f.maxLengthSet || (f.out != "" && f.out != "-") {
+		return f.MaxLength
+	}
+
+	fd := int(os.Stdout.Fd())
+	if !term.IsTerminal(fd) {
+		return f.MaxLength
+	}
+
+	width, _, err := term.GetSize(fd)
+	if err != nil {
+		return f.MaxLength
+	}
+
+	if width <= termWidthMargin {
+		return f.MaxLength
+	}
+
+	return width - termWidthMargin
+}
+
+// InFormat returns the format of input data.
+func (f *flags) InFormat() string {
+	return f.inFormat
+}
+
+// Separator returns the character to separate values and labels at, or 0 if
+// the default separators are used.
+func (f *flags) Separator() rune {
+	if f.separator == "" {
+		return 0
+	}
+
+	return []rune(f.separator)[0]
+}
+
+// CSVOptions returns options for parsing CSV input.
+func (f *flags) CSVOptions() []chart.CSVOption {
+	return []chart.CSVOption{
+		chart.WithCSVComma([]rune(f.csvComma)[0]),
+		chart.WithCSVHeader(f.csvHeader),
+	}
+}
+
+// In returns the reader to read data from. Multiple inputs are concatenated
+// and each input is closed when it has been read.
 // Caller is responsible for closing the reader.
 func (f *flags) In() (io.ReadCloser, error) {
-	if f.in == "" || f.in == "-" {
-		return os.Stdin, nil
+	switch len(f.in) {
+	case 0:
+		return openInput("")
+	case 1:
+		return openInput(f.in[0])
+	}
+
+	readers := make([]io.Reader, 0, len(f.in)*2)
+	closers := make([]io.Closer, 0, len(f.in))
+
+	for i, name := range f.in {
+		r, err := openInput(name)
+		if err != nil {
+			for _, c := range closers {
+				c.Close()
+			}
+
+			return nil, fmt.Errorf("opening %s: %w", name, err)
+		}
+
+		if i > 0 {
+			// Separate inputs so the last line of one input isn't joined with
+			// the first line of the next.
+			readers = append(readers, strings.NewReader("\n"))
+		}
+
+		ec := &eofCloser{rc: r}
+		readers = append(readers, ec)
+		closers = append(closers, ec)
+	}
+
+	return &multiReadCloser{Reader: io.MultiReader(readers...), closers: closers}, nil
+}
+
+// openInput opens a single input, which is stdin if name is empty or "-", an
+// HTTP(S) URL, or a file path.
+func openInput(name string) (io.ReadCloser, error) {
+	var (
+		r   io.ReadCloser
+		err error
+	)
+
+	switch {
+	case name == "" || name == "-":
+		r = os.Stdin
+	case strings.HasPrefix(name, "http://") || strings.HasPrefix(name, "https://"):
+		r, err = httpGet(name)
+	default:
+		r, err = os.Open(name)
+		if err != nil {
+			err = fmt.Errorf("opening file: %w", err)
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return decompress(r)
+}
+
+// eofCloser closes the underlying reader as soon as it has been read to EOF.
+type eofCloser struct {
+	rc     io.ReadCloser
+	closed bool
+}
+
+func (r *eofCloser) Read(p []byte) (int, error) {
+	n, err := r.rc.Read(p)
+	if errors.Is(err, io.EOF) {
+		r.Close()
+	}
+
+	return n, err //nolint:wrapcheck // io.EOF must not be wrapped.
+}
+
+func (r *eofCloser) Close() error {
+	if r.closed {
+		return nil
+	}
+
+	r.closed = true
+
+	return r.rc.Close() //nolint:wrapcheck // passthrough of underlying reader.
+}
+
+// multiReadCloser reads inputs sequentially and closes all of them when
+// closed.
+type multiReadCloser struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (r *multiReadCloser) Close() error {
+	errs := make([]error, 0, len(r.closers))
+	for _, c := range r.closers {
+		errs = append(errs, c.Close())
+	}
+
+	return errors.Join(errs...)
+}
+
+// gzipMagic is the header that gzip compressed data starts with.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// gzipReadCloser closes both the gzip reader and the underlying reader.
+type gzipReadCloser struct {
+	*gzip.Reader
+	underlying io.Closer
+}
+
+func (r *gzipReadCloser) Close() error {
+	return errors.Join(r.Reader.Close(), r.underlying.Close())
+}
+
+// decompress returns a reader that transparently decompresses r if it
+// contains gzip compressed data. Other data is returned unchanged.
+func decompress(r io.ReadCloser) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+
+	magic, _ := br.Peek(len(gzipMagic))
+	if !bytes.Equal(magic, gzipMagic) {
+		return struct {
+			io.Reader
+			io.Closer
+		}{br, r}, nil
 	}
 
-	r, err := os.Open(f.in)
+	gr, err := gzip.NewReader(br)
 	if err != nil {
-		return nil, fmt.Errorf("opening file: %w", err)
+		r.Close()
+		return nil, fmt.Errorf("decompressing gzip data: %w", err)
 	}
 
-	return r, nil
+	return &gzipReadCloser{Reader: gr, underlying: r}, nil
+}
+
+// httpGet performs a GET request to url and returns the response body.
+// Returns an error if the response status is not 200 OK.
+func httpGet(url string) (io.ReadCloser, error) {
+	client := &http.Client{Timeout: httpTimeout}
+
+	resp, err := client.Get(url) //nolint:noctx // client has a timeout.
+	if err != nil {
+		return nil, fmt.Errorf("requesting URL: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("requesting URL: unexpected response status: %s", resp.Status)
+	}
+
+	return resp.Body, nil
 }
 
 // Out returns the writer to write chart to.
@@ -114,31 +479,267 @@ func parseFlags(args []string) (*flags, error) {
 	flags := flags{}
 
 	boolFlag(flagset, &flags.Count, "count", "c", false, "count line occurrences")
+	boolFlag(flagset, &flags.Sum, "sum", "A", false, "sum values of repeated labels")
+	intFlag(flagset, &flags.Histogram, "histogram", "", 0, "chart histogram of values with number of bins")
+	intFlag(flagset, &flags.Limit, "limit", "N", 0, "stop reading after number of parsed lines")
+	boolFlag(flagset, &flags.Strict, "strict", "", false, "fail on the first unparsable line")
+	stringFlag(flagset, &flags.Comment, "comment", "", chart.DefaultCommentPrefix, "prefix of comment lines")
+	stringFlag(flagset, &flags.separator, "separator", "", "", "only separate values and labels at character")
 	intFlag(flagset, &flags.MaxLength, "length", "l", defaultMaxLength, "maximum bar length")
 	intFlag(flagset, &flags.MaxLabelLength, "label-length", "L", defaultMaxLabelLength, "maximum label length")
+	stringFlag(flagset, &flags.labelAlign, "label-align", "", "right", "label alignment")
 	intFlag(flagset, &flags.Precision, "precision", "p", defaultPrecision, "precision for values")
 	boolFlag(flagset, &flags.Scale, "scale", "S", false, "scale bars logarithmically")
+	intFlag(flagset, &flags.Top, "top", "n", 0, "only keep labels with the highest values")
+	floatFlag(flagset, &flags.MinValue, "min-value", "", 0, "drop labels with values below minimum")
+	floatFlag(flagset, &flags.ScaleBy, "scale-by", "", 1, "multiply values by factor")
+	stringFlag(flagset, &flags.OtherLabel, "other-label", "", "", "label for bucket of remaining values (with --top)")
+	stringFlag(flagset, &flags.Format, "format", "f", defaultFormat, "output format")
 	boolFlag(flagset, &flags.Mermaid, "mermaid", "m", false, "create Mermaid XYChart")
 	boolFlag(flagset, &flags.Chartjs, "chartjs", "C", false, "create Chart.js configuration")
+	boolFlag(flagset, &flags.JSON, "json", "j", false, "create JSON data")
+	boolFlag(flagset, &flags.Sparkline, "sparkline", "", false, "create sparkline")
+	stringFlag(flagset, &flags.colors, "colors", "", "", "comma-separated bar colors (chartjs, svg)")
+	stringFlag(flagset, &flags.diverging, "diverging-colors", "", "", "colors for positive and negative values (chartjs)")
+	boolFlag(flagset, &flags.Standalone, "standalone", "", false, "create complete HTML document (chartjs, html)")
+	boolFlag(flagset, &flags.NoGrid, "no-grid", "", false, "hide gridlines (chartjs)")
+	boolFlag(flagset, &flags.BeginAtZero, "begin-at-zero", "", false, "start value axis at zero (chartjs)")
+	boolFlag(flagset, &flags.Horizontal, "horizontal", "", false, "draw horizontal bars (chartjs)")
 	boolFlag(flagset, &flags.Version, "version", "v", false, "Display version information and exit")
-	stringFlag(flagset, &flags.Title, "title", "T", "", "chart title (mermaid, chartjs)")
-	stringFlag(flagset, &flags.in, "in", "i", "", "read data from file")
+	stringFlag(flagset, &flags.Title, "title", "T", "", "chart title (mermaid, chartjs, html, svg, gnuplot, vegalite)")
+	stringFlag(flagset, &flags.Unit, "unit", "u", "", "unit suffix for values (simple)")
+	boolFlag(flagset, &flags.Axis, "axis", "", false, "display axis above bars (simple)")
+	boolFlag(flagset, &flags.Footer, "footer", "", false, "display footer with total (simple)")
+	boolFlag(flagset, &flags.ASCII, "ascii", "", false, "draw bars with ASCII characters only (simple)")
+	boolFlag(flagset, &flags.NoSmallTick, "no-small-tick", "", false, "draw nothing for zero-length bars (simple)")
+	boolFlag(flagset, &flags.Vertical, "vertical", "", false, "draw bars as vertical columns (simple)")
+	boolFlag(flagset, &flags.Percentages, "percentages", "P", false, "display percentage of total (simple)")
+	boolFlag(flagset, &flags.Group, "group", "", false, "group digits of values with thousands separators (simple, csv, markdown)")
+	stringFlag(flagset, &flags.Locale, "locale", "", numfmt.DefaultLocale, "locale for formatting values (simple, csv)")
+	boolFlag(flagset, &flags.Watch, "watch", "", false, "re-render chart when input files change (simple)")
+	boolFlag(flagset, &flags.Stream, "stream", "", false, "redraw chart while input is read (simple)")
+	durationFlag(flagset, &flags.Interval, "interval", "", defaultInterval, "interval for checking input files or redrawing")
+	stringsFlag(flagset, &flags.in, "in", "i", "read data from file (repeatable)")
+	stringFlag(flagset, &flags.inFormat, "in-format", "", defaultInFormat, "input data format")
+	stringFlag(flagset, &flags.csvComma, "csv-comma", "", ",", "CSV input field delimiter")
+	boolFlag(flagset, &flags.csvHeader, "csv-header", "", false, "skip first CSV input record as header")
 	stringFlag(flagset, &flags.out, "out", "o", "", "write chart to file")
 	stringFlag(flagset, &flags.sort, "sort", "s", defaultSort, "chart sorting option")
 	boolFlag(flagset, &flags.desc, "desc", "d", false, "sort chart in descending order")
+	boolFlag(flagset, &flags.reverse, "reverse", "r", false, "reverse order of bars")
 	stringFlag(flagset, &flags.tick, "tick", "t", "", "use symbol for drawing bars")
+	stringFlag(flagset, &flags.include, "include", "", "", "only chart labels matching regular expression")
+	stringFlag(flagset, &flags.exclude, "exclude", "", "", "don't chart labels matching regular expression")
 
 	if err := flagset.Parse(args); err != nil {
 		return nil, fmt.Errorf("parsing flags: %w", err)
 	}
 
+	var formatSet bool
+
+	flagset.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "length", "l":
+			flags.maxLengthSet = true
+		case "min-value":
+			flags.minValueSet = true
+		case "precision", "p":
+			flags.precisionSet = true
+		case "format", "f":
+			formatSet = true
+		}
+	})
+
+	if err := flags.resolveFormat(formatSet); err != nil {
+		return nil, err
+	}
+
+	if flags.Count && flags.Sum {
+		return nil, errors.New("count and sum modes can't be combined")
+	}
+
+	if flags.Histogram < 0 {
+		return nil, errors.New("number of histogram bins must be a positive integer")
+	}
+
+	if flags.Limit < 0 {
+		return nil, errors.New("limit must be a non-negative integer")
+	}
+
+	if !slices.Contains(inFormats, flags.inFormat) {
+		return nil, fmt.Errorf("unknown input format %q", flags.inFormat)
+	}
+
+	if err := flags.validateInFormat(); err != nil {
+		return nil, err
+	}
+
+	if flags.separator != "" && utf8.RuneCountInString(flags.separator) != 1 {
+		return nil, errors.New("separator must be a single character")
+	}
+
+	if utf8.RuneCountInString(flags.csvComma) != 1 {
+		return nil, errors.New("CSV delimiter must be a single character")
+	}
+
+	if _, ok := labelAlignMap[flags.labelAlign]; !ok {
+		return nil, fmt.Errorf("unknown label alignment %q", flags.labelAlign)
+	}
+
+	if flags.diverging != "" {
+		if colors := splitColors(flags.diverging); len(colors) != 2 || colors[0] == "" || colors[1] == "" {
+			return nil, errors.New("diverging colors must be two comma-separated colors")
+		}
+	}
+
+	if _, ok := numfmt.Locales[flags.Locale]; !ok {
+		return nil, fmt.Errorf("unknown locale %q", flags.Locale)
+	}
+
 	if _, ok := sortOptMap[flags.sort]; !ok {
 		return nil, fmt.Errorf("unknown sort option %q", flags.sort)
 	}
 
+	if err := flags.validateWatch(); err != nil {
+		return nil, err
+	}
+
+	if err := flags.validateStream(); err != nil {
+		return nil, err
+	}
+
+	if flags.include != "" {
+		re, err := regexp.Compile(flags.include)
+		if err != nil {
+			return nil, fmt.Errorf("parsing include pattern: %w", err)
+		}
+
+		flags.includeRE = re
+	}
+
+	if flags.exclude != "" {
+		re, err := regexp.Compile(flags.exclude)
+		if err != nil {
+			return nil, fmt.Errorf("parsing exclude pattern: %w", err)
+		}
+
+		flags.excludeRE = re
+	}
+
 	return &flags, nil
 }
 
+// validateInFormat validates that line parsing flags are only combined with
+// the lines input format.
+func (f *flags) validateInFormat() error {
+	if f.inFormat == "lines" {
+		return nil
+	}
+
+	var name string
+
+	switch {
+	case f.Count:
+		name = "count"
+	case f.Sum:
+		name = "sum"
+	case f.Strict:
+		name = "strict"
+	case f.Limit > 0:
+		name = "limit"
+	case f.separator != "":
+		name = "separator"
+	case f.include != "":
+		name = "include"
+	case f.exclude != "":
+		name = "exclude"
+	default:
+		return nil
+	}
+
+	return fmt.Errorf("--%s is not supported by input format %q", name, f.inFormat)
+}
+
+// validateWatch validates flags for watch mode.
+func (f *flags) validateWatch() error {
+	if !f.Watch {
+		return nil
+	}
+
+	if f.Format != "simple" {
+		return fmt.Errorf("watch mode is not supported by output format %q", f.Format)
+	}
+
+	if f.Interval <= 0 {
+		return errors.New("watch interval must be a positive duration")
+	}
+
+	if len(f.in) == 0 {
+		return errors.New("watch mode requires input files")
+	}
+
+	for _, name := range f.in {
+		if name == "-" || strings.HasPrefix(name, "http://") || strings.HasPrefix(name, "https://") {
+			return fmt.Errorf("watch mode requires input files, got %q", name)
+		}
+	}
+
+	return nil
+}
+
+// validateStream validates flags for stream mode.
+func (f *flags) validateStream() error {
+	if !f.Stream {
+		return nil
+	}
+
+	switch {
+	case f.Watch:
+		return errors.New("stream mode can't be combined with watch mode")
+	case f.Format != "simple":
+		return fmt.Errorf("stream mode is not supported by output format %q", f.Format)
+	case f.Interval <= 0:
+		return errors.New("stream interval must be a positive duration")
+	case f.InFormat() != "lines" || f.Histogram > 0:
+		return errors.New("stream mode only supports charting data lines")
+	}
+
+	return nil
+}
+
+// resolveFormat sets the output format from format shorthand flags and
+// validates it. Returns an error if conflicting formats are given.
+func (f *flags) resolveFormat(formatSet bool) error {
+	aliases := []struct {
+		enabled bool
+		format  string
+	}{
+		{f.Mermaid, "mermaid"},
+		{f.Chartjs, "chartjs"},
+		{f.JSON, "json"},
+		{f.Sparkline, "sparkline"},
+	}
+
+	for _, alias := range aliases {
+		if !alias.enabled {
+			continue
+		}
+
+		if formatSet && f.Format != alias.format {
+			return fmt.Errorf("conflicting output formats %q and %q", f.Format, alias.format)
+		}
+
+		f.Format = alias.format
+		formatSet = true
+	}
+
+	if _, ok := renderers[f.Format]; !ok {
+		return fmt.Errorf("unknown output format %q", f.Format)
+	}
+
+	return nil
+}
+
 // printUsage prints application usage to stderr.
 // If an error is given, it is printed above the usage.
 func printUsage(err error) {
@@ -156,6 +757,13 @@ func boolFlag(flagset *flag.FlagSet, p *bool, name, short string, value bool, us
 	}
 }
 
+func floatFlag(flagset *flag.FlagSet, p *float64, name, short string, value float64, usage string) { //nolint:revive // acceptable arg count.
+	flagset.Float64Var(p, name, value, usage)
+	if short != "" {
+		flagset.Float64Var(p, short, value, usage)
+	}
+}
+
 func intFlag(flagset *flag.FlagSet, p *int, name, short string, value int, usage string) { //nolint:revive // acceptable arg count.
 	flagset.IntVar(p, name, value, usage)
 	if short != "" {
@@ -163,6 +771,32 @@ func intFlag(flagset *flag.FlagSet, p *int, name, short string, value int, usage
 	}
 }
 
+func durationFlag(flagset *flag.FlagSet, p *time.Duration, name, short string, value time.Duration, usage string) { //nolint:revive // acceptable arg count.
+	flagset.DurationVar(p, name, value, usage)
+	if short != "" {
+		flagset.DurationVar(p, short, value, usage)
+	}
+}
+
+func stringsFlag(flagset *flag.FlagSet, p *[]string, name, short, usage string) {
+	flagset.Var((*stringsValue)(p), name, usage)
+	if short != "" {
+		flagset.Var((*stringsValue)(p), short, usage)
+	}
+}
+
+// stringsValue is a [flag.Value] collecting the values of a repeatable flag.
+type stringsValue []string
+
+func (v *stringsValue) String() string {
+	return strings.Join(*v, ",")
+}
+
+func (v *stringsValue) Set(s string) error {
+	*v = append(*v, s)
+	return nil
+}
+
 func stringFlag(flagset *flag.FlagSet, p *string, name, short, value, usage string) { //nolint:revive // acceptable arg count.
 	flagset.StringVar(p, name, value, usage)
 	if short != "" {
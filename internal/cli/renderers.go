@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"github.com/michenriksen/chart"
+	"github.com/michenriksen/chart/chartjs"
+	"github.com/michenriksen/chart/csvr"
+	"github.com/michenriksen/chart/gnuplot"
+	"github.com/michenriksen/chart/html"
+	"github.com/michenriksen/chart/jsonr"
+	"github.com/michenriksen/chart/markdown"
+	"github.com/michenriksen/chart/mermaid"
+	"github.com/michenriksen/chart/simple"
+	"github.com/michenriksen/chart/sparkline"
+	"github.com/michenriksen/chart/svg"
+	"github.com/michenriksen/chart/vegalite"
+)
+
+// rendererFunc creates a renderer configured from flags.
+type rendererFunc func(*flags) (chart.Renderer, error)
+
+// renderers maps output format names to renderer constructors.
+var renderers = map[string]rendererFunc{
+	"simple": func(f *flags) (chart.Renderer, error) {
+		opts := []simple.RendererOption{
+			simple.WithMaxLength(f.ChartLength()),
+			simple.WithMaxLabelLength(f.MaxLabelLength),
+			simple.WithLabelAlign(f.LabelAlign()),
+			simple.WithScaleMode(f.ScaleMode()),
+			simple.WithTick(f.Tick()),
+			simple.WithValueUnit(f.Unit),
+			simple.WithPercentages(f.Percentages),
+			simple.WithAxis(f.Axis),
+			simple.WithFooter(f.Footer),
+			simple.WithOrientation(f.Orientation()),
+			simple.WithASCII(f.ASCII),
+			simple.WithGrouping(f.Group),
+			simple.WithLocale(f.Locale),
+		}
+
+		if f.NoSmallTick {
+			opts = append(opts, simple.WithMinBarGlyph(0))
+		}
+
+		return simple.NewRenderer(opts...)
+	},
+	"mermaid": func(f *flags) (chart.Renderer, error) {
+		return mermaid.NewRenderer(mermaid.WithTitle(f.Title))
+	},
+	"chartjs": func(f *flags) (chart.Renderer, error) {
+		opts := []chartjs.RendererOption{
+			chartjs.WithTitle(f.Title),
+			chartjs.WithHorizontal(f.Horizontal),
+			chartjs.WithStandaloneHTML(f.Standalone),
+		}
+
+		if colors := f.Colors(); colors != nil {
+			opts = append(opts, chartjs.WithColors(colors))
+		}
+
+		if pos, neg := f.DivergingColors(); pos != "" {
+			opts = append(opts, chartjs.WithDivergingColors(pos, neg))
+		}
+
+		if f.NoGrid {
+			opts = append(opts, chartjs.WithGrid(false))
+		}
+
+		if f.BeginAtZero {
+			opts = append(opts, chartjs.WithBeginAtZero(true))
+		}
+
+		return chartjs.NewRenderer(opts...)
+	},
+	"json": func(*flags) (chart.Renderer, error) {
+		return jsonr.NewRenderer(jsonr.WithIndent(true))
+	},
+	"csv": func(f *flags) (chart.Renderer, error) {
+		return csvr.NewRenderer(
+			csvr.WithGrouping(f.Group),
+			csvr.WithLocale(f.Locale),
+		)
+	},
+	"markdown": func(f *flags) (chart.Renderer, error) {
+		return markdown.NewRenderer(markdown.WithGrouping(f.Group))
+	},
+	"html": func(f *flags) (chart.Renderer, error) {
+		return html.NewRenderer(
+			html.WithTitle(f.Title),
+			html.WithStandalone(f.Standalone),
+		)
+	},
+	"svg": func(f *flags) (chart.Renderer, error) {
+		opts := []svg.RendererOption{svg.WithTitle(f.Title)}
+
+		if colors := f.Colors(); colors != nil {
+			opts = append(opts, svg.WithColors(colors))
+		}
+
+		return svg.NewRenderer(opts...)
+	},
+	"gnuplot": func(f *flags) (chart.Renderer, error) {
+		return gnuplot.NewRenderer(gnuplot.WithTitle(f.Title))
+	},
+	"vegalite": func(f *flags) (chart.Renderer, error) {
+		return vegalite.NewRenderer(vegalite.WithTitle(f.Title))
+	},
+	"sparkline": func(*flags) (chart.Renderer, error) {
+		return sparkline.NewRenderer()
+	},
+}
+
+// registerRenderer registers a renderer constructor for an output format,
+// replacing any existing constructor for the format.
+func registerRenderer(format string, fn rendererFunc) {
+	renderers[format] = fn
+}
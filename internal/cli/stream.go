@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/michenriksen/chart"
+	"golang.org/x/term"
+)
+
+// stream renders a chart while input is read, redrawing it on the configured
+// interval until the input is exhausted.
+func stream(flags *flags, renderer chart.Renderer, out io.Writer) int {
+	c, err := chart.New(chartOptions(flags)...)
+	if err != nil {
+		return fatal("creating chart", err)
+	}
+
+	in, err := flags.In()
+	if err != nil {
+		return fatal("opening input", err)
+	}
+	defer in.Close()
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- chart.Parse(in, c, parseOptions(flags)...)
+	}()
+
+	ticker := time.NewTicker(flags.Interval)
+	defer ticker.Stop()
+
+	fw := &frameWriter{out: out, ansi: isTerminal(out)}
+
+	for {
+		select {
+		case err := <-done:
+			if err != nil {
+				return fatal("parsing input", err)
+			}
+
+			if err := fw.draw(renderer, snapshot(flags, c)); err != nil {
+				return fatal("rendering chart", err)
+			}
+
+			return exitNormal
+		case <-ticker.C:
+			if err := fw.draw(renderer, snapshot(flags, c)); err != nil {
+				return fatal("rendering chart", err)
+			}
+		}
+	}
+}
+
+// snapshot returns a copy of c that is not modified by ongoing parsing, so
+// labels and values are consistent while the chart is rendered.
+func snapshot(flags *flags, c *chart.Chart) *chart.Chart {
+	cp, _ := chart.New(chartOptions(flags)...)
+
+	return reduce(flags, cp.Merge(c))
+}
+
+// frameWriter writes chart frames to a writer.
+//
+// On terminals, each frame overwrites the previous frame by moving the cursor
+// up. Otherwise, frames are written one after another without ANSI escape
+// sequences.
+type frameWriter struct {
+	out  io.Writer
+	ansi bool
+	prev []byte
+}
+
+// draw renders c and writes it as a frame, unless it's identical to the
+// previous frame.
+func (w *frameWriter) draw(renderer chart.Renderer, c *chart.Chart) error {
+	frame := new(bytes.Buffer)
+
+	if _, err := renderer.Render(c, frame); err != nil {
+		return fmt.Errorf("rendering chart: %w", err)
+	}
+
+	if w.prev != nil && bytes.Equal(frame.Bytes(), w.prev) {
+		return nil
+	}
+
+	buf := new(bytes.Buffer)
+
+	if w.ansi && w.prev != nil {
+		// Move the cursor to the start of the previous frame and clear from
+		// there to the end of the screen.
+		fmt.Fprintf(buf, "\033[%dA\033[J", bytes.Count(w.prev, []byte("\n")))
+	}
+
+	buf.Write(frame.Bytes())
+	w.prev = frame.Bytes()
+
+	if _, err := w.out.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("writing to out: %w", err)
+	}
+
+	return nil
+}
+
+// isTerminal returns true if w is a terminal.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
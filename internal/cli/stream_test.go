@@ -0,0 +1,65 @@
+package cli_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/michenriksen/chart/internal/cli"
+)
+
+func TestStream(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stdin := os.Stdin
+	t.Cleanup(func() { os.Stdin = stdin })
+
+	os.Stdin = r
+
+	go func() {
+		defer w.Close()
+
+		for _, line := range []string{"apple", "banana", "apple"} {
+			if _, err := w.WriteString(line + "\n"); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+
+		// Pause for long enough for the chart to be redrawn.
+		time.Sleep(200 * time.Millisecond)
+
+		if _, err := w.WriteString("cherry\n"); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	out := new(syncBuffer)
+
+	code, err := cli.Stream([]string{"--stream", "--count", "--interval", "20ms", "--length", "20"}, out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d", code)
+	}
+
+	got := out.String()
+
+	if strings.Contains(got, "\033[") {
+		t.Errorf("expected no ANSI escape sequences when not writing to a terminal, got %q", got)
+	}
+
+	if n := strings.Count(got, "banana"); n != 2 {
+		t.Errorf("expected 2 frames, got %d: %q", n, got)
+	}
+
+	if n := strings.Count(got, "cherry"); n != 1 {
+		t.Errorf("expected cherry in final frame only, got %d occurrences: %q", n, got)
+	}
+}
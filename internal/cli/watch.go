@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/michenriksen/chart"
+)
+
+// clearScreen moves the cursor to the top left corner and clears the screen.
+const clearScreen = "\033[H\033[2J"
+
+// watch renders a chart from the input files each time they change, checking
+// for changes on the configured interval until ctx is done.
+func watch(ctx context.Context, flags *flags, renderer chart.Renderer, out io.Writer) int {
+	ticker := time.NewTicker(flags.Interval)
+	defer ticker.Stop()
+
+	var last string
+
+	for {
+		state, err := inputState(flags.in)
+		if state != last {
+			last = state
+
+			if err != nil {
+				// Files are often replaced rather than written in place, so
+				// a missing file is expected to reappear.
+				slog.Warn("checking input", "error", err)
+			} else if err := renderFrame(flags, renderer, out); err != nil {
+				slog.Error("rendering chart", "error", err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return exitNormal
+		case <-ticker.C:
+		}
+	}
+}
+
+// inputState returns a string describing the modification time and size of
+// the input files, which changes when any of the files change.
+func inputState(names []string) (string, error) {
+	var b strings.Builder
+
+	for _, name := range names {
+		info, err := os.Stat(name)
+		if err != nil {
+			return err.Error(), fmt.Errorf("getting file info: %w", err)
+		}
+
+		fmt.Fprintf(&b, "%s:%d:%d\n", name, info.ModTime().UnixNano(), info.Size())
+	}
+
+	return b.String(), nil
+}
+
+// renderFrame builds the chart and writes it to out after clearing the screen.
+// The frame is written at once to avoid flickering.
+func renderFrame(flags *flags, renderer chart.Renderer, out io.Writer) error {
+	c, err := buildChart(flags)
+	if err != nil {
+		return err
+	}
+
+	buf := new(bytes.Buffer)
+	buf.WriteString(clearScreen)
+
+	if _, err := renderer.Render(c, buf); err != nil {
+		return fmt.Errorf("rendering chart: %w", err)
+	}
+
+	if _, err := out.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("writing to out: %w", err)
+	}
+
+	return nil
+}
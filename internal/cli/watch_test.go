@@ -0,0 +1,98 @@
+package cli_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/michenriksen/chart/internal/cli"
+)
+
+// syncBuffer is a buffer that is safe for concurrent use.
+type syncBuffer struct {
+	buf bytes.Buffer
+	mu  sync.Mutex
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.String()
+}
+
+func TestWatch(t *testing.T) {
+	in := filepath.Join(t.TempDir(), "input.txt")
+
+	if err := os.WriteFile(in, []byte("1 One\n2 Two\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	out := new(syncBuffer)
+	done := make(chan int)
+
+	go func() {
+		code, err := cli.Watch(ctx, []string{"--watch", "--interval", "10ms", "--in", in, "--length", "20"}, out)
+		if err != nil {
+			t.Error(err)
+		}
+
+		done <- code
+	}()
+
+	waitForFrames(t, out, 1)
+
+	if err := os.WriteFile(in, []byte("1 One\n2 Two\n3 Three\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	waitForFrames(t, out, 2)
+	cancel()
+
+	if code := <-done; code != 0 {
+		t.Errorf("expected exit code 0, got %d", code)
+	}
+
+	frames := strings.Split(out.String(), "\033[H\033[2J")[1:]
+	if len(frames) != 2 {
+		t.Fatalf("expected 2 frames, got %d: %q", len(frames), out.String())
+	}
+
+	if strings.Contains(frames[0], "Three") {
+		t.Errorf("expected first frame not to contain Three, got %q", frames[0])
+	}
+
+	if !strings.Contains(frames[1], "Three") {
+		t.Errorf("expected second frame to contain Three, got %q", frames[1])
+	}
+}
+
+// waitForFrames waits until out contains n rendered frames.
+func waitForFrames(t *testing.T, out *syncBuffer, n int) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+
+	for strings.Count(out.String(), "\033[H\033[2J") < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %d frames, got %q", n, out.String())
+		}
+
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -0,0 +1,73 @@
+// Package numfmt formats values for display in human-facing renderers.
+package numfmt
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Locale is a convention for formatting numbers.
+type Locale struct {
+	Decimal string // Decimal separator.
+	Group   string // Separator between groups of thousands.
+}
+
+// DefaultLocale is the locale used when none is configured.
+const DefaultLocale = "en"
+
+// Locales maps locale names to number formatting conventions.
+var Locales = map[string]Locale{
+	"en": {Decimal: ".", Group: ","},
+	"de": {Decimal: ",", Group: "."},
+	"fr": {Decimal: ",", Group: " "},
+}
+
+// Format returns value formatted with prec decimals and the separators of
+// loc. Digits are not grouped if the group separator of loc is empty.
+func Format(value float64, prec int, loc Locale) string {
+	s := strconv.FormatFloat(value, 'f', prec, 64)
+	if math.IsInf(value, 0) || math.IsNaN(value) {
+		return s
+	}
+
+	sign := ""
+	if s[0] == '-' {
+		sign, s = "-", s[1:]
+	}
+
+	intPart, frac, hasFrac := strings.Cut(s, ".")
+	if hasFrac {
+		frac = loc.Decimal + frac
+	}
+
+	if loc.Group != "" {
+		intPart = group(intPart, loc.Group)
+	}
+
+	return sign + intPart + frac
+}
+
+// group inserts sep between each group of three digits in digits, counting
+// from the right.
+func group(digits, sep string) string {
+	if len(digits) <= 3 {
+		return digits
+	}
+
+	var b strings.Builder
+
+	head := len(digits) % 3
+	if head == 0 {
+		head = 3
+	}
+
+	b.WriteString(digits[:head])
+
+	for i := head; i < len(digits); i += 3 {
+		b.WriteString(sep)
+		b.WriteString(digits[i : i+3])
+	}
+
+	return b.String()
+}
@@ -0,0 +1,62 @@
+package numfmt_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/michenriksen/chart/internal/numfmt"
+)
+
+func TestFormat(t *testing.T) {
+	grouped := numfmt.Locale{Decimal: ".", Group: ","}
+
+	tests := []struct {
+		value float64
+		prec  int
+		loc   numfmt.Locale
+		want  string
+	}{
+		{0, 0, grouped, "0"},
+		{12, 0, grouped, "12"},
+		{123, 2, grouped, "123.00"},
+		{1234, 0, grouped, "1,234"},
+		{12345.678, 2, grouped, "12,345.68"},
+		{123456, 0, grouped, "123,456"},
+		{1234567, 0, grouped, "1,234,567"},
+		{1234567.891, 1, numfmt.Locale{Decimal: ".", Group: " "}, "1 234 567.9"},
+		{-1, 0, grouped, "-1"},
+		{-123, 0, grouped, "-123"},
+		{-1234, 0, grouped, "-1,234"},
+		{-123456.5, 1, grouped, "-123,456.5"},
+		{-1234567, 2, grouped, "-1,234,567.00"},
+		{1234567, 0, numfmt.Locale{Decimal: "."}, "1234567"},
+		{math.Inf(1), 0, grouped, "+Inf"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := numfmt.Format(tt.value, tt.prec, tt.loc); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFormatLocales(t *testing.T) {
+	tests := []struct {
+		locale string
+		want   string
+	}{
+		{"en", "-1,234,567.89"},
+		{"de", "-1.234.567,89"},
+		{"fr", "-1 234 567,89"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			if got := numfmt.Format(-1234567.891, 2, numfmt.Locales[tt.locale]); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package jsonr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/michenriksen/chart"
+)
+
+// Renderer renders a [chart.Chart] as JSON.
+//
+// By default, the chart is rendered as a JSON object with labels as keys and
+// values as values. Keys are written in the same order as [chart.Chart.Labels].
+type Renderer struct {
+	indent bool
+	array  bool
+}
+
+type pair struct {
+	Label string  `json:"label"`
+	Value float64 `json:"value"`
+}
+
+// NewRenderer returns a [chart.Renderer] for rendering a [chart.Chart] as
+// JSON.
+func NewRenderer(opts ...RendererOption) (*Renderer, error) {
+	r := &Renderer{}
+
+	for i, opt := range opts {
+		if err := opt(r); err != nil {
+			return nil, fmt.Errorf("applying option #%d: %w", i+1, err)
+		}
+	}
+
+	return r, nil
+}
+
+// Render renders chart to out writer.
+func (r *Renderer) Render(c *chart.Chart, out io.Writer) (int, error) {
+	labels, values := c.Snapshot()
+	pairs := make([]pair, 0, len(labels))
+
+	for i, label := range labels {
+		pairs = append(pairs, pair{Label: label, Value: values[i]})
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+
+	if r.array {
+		data, err = json.Marshal(pairs)
+	} else {
+		data, err = marshalObject(pairs)
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("encoding chart: %w", err)
+	}
+
+	buf := new(bytes.Buffer)
+
+	if r.indent {
+		if err := json.Indent(buf, data, "", "  "); err != nil {
+			return 0, fmt.Errorf("indenting JSON: %w", err)
+		}
+	} else {
+		buf.Write(data)
+	}
+
+	buf.WriteByte('\n')
+
+	n, err := out.Write(buf.Bytes())
+	if err != nil {
+		return n, fmt.Errorf("writing to out: %w", err)
+	}
+
+	return n, nil
+}
+
+// marshalObject encodes pairs as a JSON object while preserving their order.
+func marshalObject(pairs []pair) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	buf.WriteByte('{')
+
+	for i, p := range pairs {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		key, err := json.Marshal(p.Label)
+		if err != nil {
+			return nil, fmt.Errorf("encoding label %q: %w", p.Label, err)
+		}
+
+		val, err := json.Marshal(p.Value)
+		if err != nil {
+			return nil, fmt.Errorf("encoding value for label %q: %w", p.Label, err)
+		}
+
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(val)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// RendererOption configures a [Renderer].
+type RendererOption func(*Renderer) error
+
+// WithIndent configures a [Renderer] to indent the JSON output.
+func WithIndent(enable bool) RendererOption {
+	return func(r *Renderer) error {
+		r.indent = enable
+		return nil
+	}
+}
+
+// WithArray configures a [Renderer] to render the chart as a JSON array of
+// objects with label and value keys instead of a single object.
+func WithArray(enable bool) RendererOption {
+	return func(r *Renderer) error {
+		r.array = enable
+		return nil
+	}
+}
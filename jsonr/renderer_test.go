@@ -0,0 +1,93 @@
+package jsonr_test
+
+import (
+	"testing"
+
+	"github.com/michenriksen/chart"
+	"github.com/michenriksen/chart/jsonr"
+)
+
+func TestRender(t *testing.T) {
+	c, err := chart.New(chart.WithSorting(chart.SortByValue, chart.OrderDesc), chart.WithPrecision(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set("b", 1.26).Set("a \"quoted\"", 3).Set("c", 2)
+
+	tests := []struct {
+		name string
+		opts []jsonr.RendererOption
+		want string
+	}{
+		{
+			name: "object",
+			want: `{"a \"quoted\"":3,"c":2,"b":1.3}` + "\n",
+		},
+		{
+			name: "object indented",
+			opts: []jsonr.RendererOption{jsonr.WithIndent(true)},
+			want: "{\n  \"a \\\"quoted\\\"\": 3,\n  \"c\": 2,\n  \"b\": 1.3\n}\n",
+		},
+		{
+			name: "array",
+			opts: []jsonr.RendererOption{jsonr.WithArray(true)},
+			want: `[{"label":"a \"quoted\"","value":3},{"label":"c","value":2},{"label":"b","value":1.3}]` + "\n",
+		},
+		{
+			name: "array indented",
+			opts: []jsonr.RendererOption{jsonr.WithArray(true), jsonr.WithIndent(true)},
+			want: "[\n" +
+				"  {\n    \"label\": \"a \\\"quoted\\\"\",\n    \"value\": 3\n  },\n" +
+				"  {\n    \"label\": \"c\",\n    \"value\": 2\n  },\n" +
+				"  {\n    \"label\": \"b\",\n    \"value\": 1.3\n  }\n" +
+				"]\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := jsonr.NewRenderer(tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := chart.RenderString(r, c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.want {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRenderEmpty(t *testing.T) {
+	c, err := chart.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, array := range []bool{false, true} {
+		r, err := jsonr.NewRenderer(jsonr.WithArray(array))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := chart.RenderString(r, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := "{}\n"
+		if array {
+			want = "[]\n"
+		}
+
+		if got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	}
+}
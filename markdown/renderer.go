@@ -0,0 +1,138 @@
+package markdown
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/michenriksen/chart"
+	"github.com/michenriksen/chart/internal/numfmt"
+)
+
+// Default option values.
+const (
+	DefaultBarLength = 20
+	DefaultTick      = '▇'
+)
+
+// Renderer renders a [chart.Chart] as a GitHub-flavored Markdown table.
+type Renderer struct {
+	bar      bool
+	barLen   int
+	grouping bool
+}
+
+// NewRenderer returns a [chart.Renderer] for rendering a [chart.Chart] as a
+// GitHub-flavored Markdown table.
+func NewRenderer(opts ...RendererOption) (*Renderer, error) {
+	r := &Renderer{
+		barLen: DefaultBarLength,
+	}
+
+	for i, opt := range opts {
+		if err := opt(r); err != nil {
+			return nil, fmt.Errorf("applying option #%d: %w", i+1, err)
+		}
+	}
+
+	return r, nil
+}
+
+// Render renders chart to out writer.
+func (r *Renderer) Render(c *chart.Chart, out io.Writer) (int, error) {
+	buf := new(bytes.Buffer)
+
+	if r.bar {
+		fmt.Fprintln(buf, "| Label | Value | |")
+		fmt.Fprintln(buf, "|---|---:|---|")
+	} else {
+		fmt.Fprintln(buf, "| Label | Value |")
+		fmt.Fprintln(buf, "|---|---:|")
+	}
+
+	loc := numfmt.Locales[numfmt.DefaultLocale]
+	if !r.grouping {
+		loc.Group = ""
+	}
+
+	labels, values := c.Snapshot()
+
+	maxVal := 0.0
+	for _, value := range values {
+		maxVal = max(maxVal, value)
+	}
+
+	for i, label := range labels {
+		value := numfmt.Format(values[i], c.Precision(), loc)
+
+		if r.bar {
+			fmt.Fprintf(buf, "| %s | %s | %s |\n", escape(label), value, r.drawBar(values[i], maxVal))
+			continue
+		}
+
+		fmt.Fprintf(buf, "| %s | %s |\n", escape(label), value)
+	}
+
+	n, err := out.Write(buf.Bytes())
+	if err != nil {
+		return n, fmt.Errorf("writing to out: %w", err)
+	}
+
+	return n, nil
+}
+
+func (r *Renderer) drawBar(value, maxVal float64) string {
+	if maxVal <= 0 {
+		return ""
+	}
+
+	length := math.Round(max(value, 0) / maxVal * float64(r.barLen))
+
+	return strings.Repeat(string(DefaultTick), int(length))
+}
+
+// escaper escapes pipes and replaces line breaks, which would otherwise end
+// the table row, with HTML line breaks.
+var escaper = strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>", "\r", "<br>")
+
+// escape escapes characters in s that would break the table structure.
+func escape(s string) string {
+	return escaper.Replace(s)
+}
+
+// RendererOption configures a [Renderer].
+type RendererOption func(*Renderer) error
+
+// WithBar configures a [Renderer] to add a column with a bar drawn with
+// Unicode block characters.
+func WithBar(enable bool) RendererOption {
+	return func(r *Renderer) error {
+		r.bar = enable
+		return nil
+	}
+}
+
+// WithBarLength configures a [Renderer] with a maximum length for bars drawn
+// with [WithBar].
+func WithBarLength(n int) RendererOption {
+	return func(r *Renderer) error {
+		if n <= 0 {
+			return errors.New("bar length must be a positive integer")
+		}
+
+		r.barLen = n
+		return nil
+	}
+}
+
+// WithGrouping configures a [Renderer] to group digits of values with
+// thousands separators, e.g. 1,234,567.
+func WithGrouping(enable bool) RendererOption {
+	return func(r *Renderer) error {
+		r.grouping = enable
+		return nil
+	}
+}
@@ -0,0 +1,71 @@
+package markdown_test
+
+import (
+	"testing"
+
+	"github.com/michenriksen/chart"
+	"github.com/michenriksen/chart/markdown"
+)
+
+func TestRender(t *testing.T) {
+	c, err := chart.New(chart.WithPrecision(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set("a|b", 4).Set("two\nlines", 2).Set("c", 1000).Set("d", -5)
+
+	tests := []struct {
+		name string
+		opts []markdown.RendererOption
+		want string
+	}{
+		{
+			name: "defaults",
+			want: "| Label | Value |\n" +
+				"|---|---:|\n" +
+				"| a\\|b | 4 |\n" +
+				"| two<br>lines | 2 |\n" +
+				"| c | 1000 |\n" +
+				"| d | -5 |\n",
+		},
+		{
+			name: "bar",
+			opts: []markdown.RendererOption{markdown.WithBar(true), markdown.WithBarLength(10)},
+			want: "| Label | Value | |\n" +
+				"|---|---:|---|\n" +
+				"| a\\|b | 4 |  |\n" +
+				"| two<br>lines | 2 |  |\n" +
+				"| c | 1000 | ▇▇▇▇▇▇▇▇▇▇ |\n" +
+				"| d | -5 |  |\n",
+		},
+		{
+			name: "grouping",
+			opts: []markdown.RendererOption{markdown.WithGrouping(true)},
+			want: "| Label | Value |\n" +
+				"|---|---:|\n" +
+				"| a\\|b | 4 |\n" +
+				"| two<br>lines | 2 |\n" +
+				"| c | 1,000 |\n" +
+				"| d | -5 |\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := markdown.NewRenderer(tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := chart.RenderString(r, c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.want {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.want, got)
+			}
+		})
+	}
+}
@@ -2,8 +2,10 @@ package mermaid
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 
 	"github.com/michenriksen/chart"
@@ -13,7 +15,14 @@ import (
 //
 // See: https://mermaid.js.org/syntax/xyChart.html
 type Renderer struct {
-	title string
+	title  string
+	xTitle string
+	yTitle string
+	yRange bool
+	yMin   float64
+	yMax   float64
+	bar    bool
+	line   bool
 }
 
 // NewRenderer returns a [chart.Renderer] for rendering a [chart.Chart] as a
@@ -21,7 +30,7 @@ type Renderer struct {
 //
 // See: https://mermaid.js.org/syntax/xyChart.html
 func NewRenderer(opts ...RendererOption) (*Renderer, error) {
-	r := &Renderer{}
+	r := &Renderer{bar: true}
 
 	for i, opt := range opts {
 		if err := opt(r); err != nil {
@@ -29,21 +38,23 @@ func NewRenderer(opts ...RendererOption) (*Renderer, error) {
 		}
 	}
 
+	if !r.bar && !r.line {
+		return nil, errors.New("at least one of bar or line must be enabled")
+	}
+
 	return r, nil
 }
 
 // Render renders chart to out writer.
+//
+// If the chart has series values, a bar and/or line is rendered for each
+// series.
 func (r *Renderer) Render(c *chart.Chart, out io.Writer) (int, error) {
-	labels := c.Labels()
-	values := make([]string, 0, len(labels))
+	labels, values := c.Snapshot()
 
-	for _, label := range labels {
-		value, err := c.Value(label)
-		if err != nil {
-			return 0, fmt.Errorf("getting value for %q label: %w", label, err)
-		}
-
-		values = append(values, fmt.Sprintf("%g", value))
+	bars, err := r.bars(c, labels, values)
+	if err != nil {
+		return 0, err
 	}
 
 	buf := new(bytes.Buffer)
@@ -51,11 +62,45 @@ func (r *Renderer) Render(c *chart.Chart, out io.Writer) (int, error) {
 	fmt.Fprintln(buf, "xychart-beta")
 
 	if r.title != "" {
-		fmt.Fprintf(buf, "  title \"%s\"\n", r.title)
+		fmt.Fprintf(buf, "  title %s\n", quote(r.title))
+	}
+
+	quoted := make([]string, 0, len(labels))
+	for _, label := range labels {
+		quoted = append(quoted, quote(label))
+	}
+
+	xAxis := "  x-axis "
+	if r.xTitle != "" {
+		xAxis += quote(r.xTitle) + " "
+	}
+
+	fmt.Fprintf(buf, "%s[%s]\n", xAxis, strings.Join(quoted, ", "))
+
+	if r.yTitle != "" || r.yRange {
+		yAxis := []string{"  y-axis"}
+		if r.yTitle != "" {
+			yAxis = append(yAxis, quote(r.yTitle))
+		}
+
+		if r.yRange {
+			yAxis = append(yAxis, fmt.Sprintf("%g --> %g", r.yMin, r.yMax))
+		}
+
+		fmt.Fprintln(buf, strings.Join(yAxis, " "))
 	}
 
-	fmt.Fprintf(buf, "  x-axis [\"%s\"]\n", strings.Join(labels, `", "`))
-	fmt.Fprintf(buf, "  bar [%s]\n", strings.Join(values, ", "))
+	if r.bar {
+		for _, values := range bars {
+			fmt.Fprintf(buf, "  bar [%s]\n", strings.Join(values, ", "))
+		}
+	}
+
+	if r.line {
+		for _, values := range bars {
+			fmt.Fprintf(buf, "  line [%s]\n", strings.Join(values, ", "))
+		}
+	}
 
 	n, err := out.Write(buf.Bytes())
 	if err != nil {
@@ -65,6 +110,49 @@ func (r *Renderer) Render(c *chart.Chart, out io.Writer) (int, error) {
 	return n, nil
 }
 
+// bars returns formatted bar values for each of the chart's series, or for
+// the chart values if the chart has no series.
+func (*Renderer) bars(c *chart.Chart, labels []string, values []float64) ([][]string, error) {
+	series := c.Series()
+	if len(series) == 0 {
+		bar := make([]string, 0, len(values))
+
+		for _, value := range values {
+			bar = append(bar, strconv.FormatFloat(value, 'f', c.Precision(), 64))
+		}
+
+		return [][]string{bar}, nil
+	}
+
+	bars := make([][]string, 0, len(series))
+
+	for _, name := range series {
+		values := make([]string, 0, len(labels))
+
+		for _, label := range labels {
+			value, err := c.SeriesValue(label, name)
+			if err != nil {
+				return nil, fmt.Errorf("getting %q series value for %q label: %w", name, label, err)
+			}
+
+			values = append(values, strconv.FormatFloat(value, 'f', c.Precision(), 64))
+		}
+
+		bars = append(bars, values)
+	}
+
+	return bars, nil
+}
+
+// quoteReplacer escapes double quotes as Mermaid entity codes and replaces
+// line breaks, which would otherwise end the string.
+var quoteReplacer = strings.NewReplacer(`"`, "#quot;", "\r\n", " ", "\n", " ", "\r", " ")
+
+// quote returns s as a double-quoted Mermaid string.
+func quote(s string) string {
+	return `"` + quoteReplacer.Replace(s) + `"`
+}
+
 // RendererOption configures a [Renderer].
 type RendererOption func(*Renderer) error
 
@@ -75,3 +163,52 @@ func WithTitle(title string) RendererOption {
 		return nil
 	}
 }
+
+// WithXAxisTitle configures a [Renderer] with a title for the x-axis.
+func WithXAxisTitle(title string) RendererOption {
+	return func(r *Renderer) error {
+		r.xTitle = title
+		return nil
+	}
+}
+
+// WithYAxisTitle configures a [Renderer] with a title for the y-axis.
+func WithYAxisTitle(title string) RendererOption {
+	return func(r *Renderer) error {
+		r.yTitle = title
+		return nil
+	}
+}
+
+// WithYRange configures a [Renderer] with an explicit range for the y-axis.
+// By default, Mermaid scales the y-axis automatically to fit chart values.
+func WithYRange(minVal, maxVal float64) RendererOption {
+	return func(r *Renderer) error {
+		if minVal >= maxVal {
+			return errors.New("minimum y-axis value must be less than maximum value")
+		}
+
+		r.yRange = true
+		r.yMin = minVal
+		r.yMax = maxVal
+		return nil
+	}
+}
+
+// WithBar configures whether a [Renderer] draws chart values as bars.
+// Bars are drawn by default.
+func WithBar(enable bool) RendererOption {
+	return func(r *Renderer) error {
+		r.bar = enable
+		return nil
+	}
+}
+
+// WithLine configures whether a [Renderer] draws chart values as a line.
+// Combine with [WithBar] to draw only a line.
+func WithLine(enable bool) RendererOption {
+	return func(r *Renderer) error {
+		r.line = enable
+		return nil
+	}
+}
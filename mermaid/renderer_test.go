@@ -0,0 +1,184 @@
+package mermaid_test
+
+import (
+	"testing"
+
+	"github.com/michenriksen/chart"
+	"github.com/michenriksen/chart/mermaid"
+)
+
+func TestRenderSeries(t *testing.T) {
+	c, err := chart.New(chart.WithPrecision(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.SetSeries("Q1", "2023", 10).
+		SetSeries("Q1", "2024", 12).
+		SetSeries("Q2", "2023", 8).
+		SetSeries("Q3", "2024", 15)
+
+	r, err := mermaid.NewRenderer()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := chart.RenderString(r, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `xychart-beta
+  x-axis ["Q1", "Q2", "Q3"]
+  bar [10, 8, 0]
+  bar [12, 0, 15]
+`
+
+	if got != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestRenderAxes(t *testing.T) {
+	c, err := chart.New(chart.WithPrecision(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set("Jan", 1.5).Set("Feb \"short\"", 3)
+
+	tests := []struct {
+		name string
+		opts []mermaid.RendererOption
+		want string
+	}{
+		{
+			name: "defaults",
+			want: "xychart-beta\n" +
+				"  x-axis [\"Jan\", \"Feb #quot;short#quot;\"]\n" +
+				"  bar [1.5, 3.0]\n",
+		},
+		{
+			name: "title",
+			opts: []mermaid.RendererOption{mermaid.WithTitle("Sales\n2024")},
+			want: "xychart-beta\n" +
+				"  title \"Sales 2024\"\n" +
+				"  x-axis [\"Jan\", \"Feb #quot;short#quot;\"]\n" +
+				"  bar [1.5, 3.0]\n",
+		},
+		{
+			name: "y range",
+			opts: []mermaid.RendererOption{mermaid.WithYRange(-1, 10.5)},
+			want: "xychart-beta\n" +
+				"  x-axis [\"Jan\", \"Feb #quot;short#quot;\"]\n" +
+				"  y-axis -1 --> 10.5\n" +
+				"  bar [1.5, 3.0]\n",
+		},
+		{
+			name: "x-axis title",
+			opts: []mermaid.RendererOption{mermaid.WithXAxisTitle("Month")},
+			want: "xychart-beta\n" +
+				"  x-axis \"Month\" [\"Jan\", \"Feb #quot;short#quot;\"]\n" +
+				"  bar [1.5, 3.0]\n",
+		},
+		{
+			name: "y-axis title",
+			opts: []mermaid.RendererOption{mermaid.WithYAxisTitle("Revenue")},
+			want: "xychart-beta\n" +
+				"  x-axis [\"Jan\", \"Feb #quot;short#quot;\"]\n" +
+				"  y-axis \"Revenue\"\n" +
+				"  bar [1.5, 3.0]\n",
+		},
+		{
+			name: "all axis options",
+			opts: []mermaid.RendererOption{
+				mermaid.WithXAxisTitle("Month"),
+				mermaid.WithYAxisTitle("Revenue"),
+				mermaid.WithYRange(0, 5),
+			},
+			want: "xychart-beta\n" +
+				"  x-axis \"Month\" [\"Jan\", \"Feb #quot;short#quot;\"]\n" +
+				"  y-axis \"Revenue\" 0 --> 5\n" +
+				"  bar [1.5, 3.0]\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := mermaid.NewRenderer(tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := chart.RenderString(r, c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.want {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWithYRangeInvalid(t *testing.T) {
+	for _, rng := range [][2]float64{{5, 5}, {10, 0}} {
+		if _, err := mermaid.NewRenderer(mermaid.WithYRange(rng[0], rng[1])); err == nil {
+			t.Errorf("expected error for y-axis range %v", rng)
+		}
+	}
+}
+
+func TestRenderLine(t *testing.T) {
+	c, err := chart.New(chart.WithPrecision(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set("Jan", 2).Set("Feb", 5)
+
+	tests := []struct {
+		name string
+		opts []mermaid.RendererOption
+		want string
+	}{
+		{
+			name: "line only",
+			opts: []mermaid.RendererOption{mermaid.WithBar(false), mermaid.WithLine(true)},
+			want: "xychart-beta\n" +
+				"  x-axis [\"Jan\", \"Feb\"]\n" +
+				"  line [2, 5]\n",
+		},
+		{
+			name: "bar and line",
+			opts: []mermaid.RendererOption{mermaid.WithLine(true)},
+			want: "xychart-beta\n" +
+				"  x-axis [\"Jan\", \"Feb\"]\n" +
+				"  bar [2, 5]\n" +
+				"  line [2, 5]\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := mermaid.NewRenderer(tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := chart.RenderString(r, c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.want {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.want, got)
+			}
+		})
+	}
+
+	if _, err := mermaid.NewRenderer(mermaid.WithBar(false)); err == nil {
+		t.Error("expected error when both bar and line are disabled")
+	}
+}
@@ -0,0 +1,54 @@
+package chart
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// RenderTarget pairs a [Renderer] with the writer to render to.
+type RenderTarget struct {
+	Renderer
+	io.Writer
+}
+
+// multiRenderer renders a chart with multiple renderers.
+type multiRenderer struct {
+	targets []RenderTarget
+}
+
+// MultiRenderer returns a [Renderer] that renders a chart with each target's
+// renderer to its writer, e.g. to render a chart for the terminal and as a
+// Chart.js configuration file without parsing the data twice. Targets
+// without a writer render to the writer passed to Render.
+//
+// All targets are rendered even if some fail. Render returns the total number
+// of bytes written and the errors of failed targets joined together.
+func MultiRenderer(targets ...RenderTarget) Renderer {
+	return &multiRenderer{targets: targets}
+}
+
+// Render renders chart with each target and writes it to the target's writer,
+// or out if the target has no writer.
+func (m *multiRenderer) Render(c *Chart, out io.Writer) (int, error) {
+	var (
+		total int
+		errs  []error
+	)
+
+	for i, t := range m.targets {
+		w := t.Writer
+		if w == nil {
+			w = out
+		}
+
+		n, err := t.Render(c, w)
+		total += n
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("rendering target #%d: %w", i+1, err))
+		}
+	}
+
+	return total, errors.Join(errs...)
+}
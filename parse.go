@@ -0,0 +1,643 @@
+package chart
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultCommentPrefix is the default prefix of comment lines.
+const DefaultCommentPrefix = "#"
+
+// ParseOption configures parsing of data.
+type ParseOption func(*parser) error
+
+// parser parses data according to configuration.
+type parser struct {
+	thousandsSep     rune
+	decimalSep       rune
+	percentFractions bool
+	count            bool
+	sum              bool
+	limit            int
+	strict           bool
+	comment          string
+	sepRE            *regexp.Regexp
+	firstToken       bool
+	filter           func(label string, value float64) bool
+	clamp            *[2]float64
+	warnFn           func(*LineError)
+}
+
+// LineError describes a data line that could not be parsed.
+type LineError struct {
+	Line   string // Line text.
+	Number int    // Line number, starting at 1.
+	Err    error  // Parsing error.
+}
+
+// Error implements the error interface.
+func (e *LineError) Error() string {
+	if e.Number > 0 {
+		return fmt.Sprintf("parsing line %d %q: %v", e.Number, e.Line, e.Err)
+	}
+
+	return fmt.Sprintf("parsing line %q: %v", e.Line, e.Err)
+}
+
+// Unwrap returns the underlying parsing error.
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// Record represents a parsed data line.
+type Record struct {
+	Label   string  // Label text.
+	Value   float64 // Numeric value.
+	Percent bool    // Whether value was given as a percentage.
+}
+
+func newParser(opts []ParseOption) (*parser, error) {
+	p := &parser{decimalSep: '.', comment: DefaultCommentPrefix, sepRE: dataSepRE}
+
+	for i, opt := range opts {
+		if err := opt(p); err != nil {
+			return nil, fmt.Errorf("applying option #%d: %w", i+1, err)
+		}
+	}
+
+	return p, nil
+}
+
+// Parse reads data lines from r and sets their values in the chart.
+//
+// Empty lines and comment lines starting with # are skipped; use
+// [WithCommentPrefix] to configure the prefix. Lines are parsed with
+// [ParseLine] and lines that can't be parsed are skipped. Use [WithWarningFunc]
+// to be notified of skipped lines, or [WithStrict] to fail on the first line
+// that can't be parsed. Use [WithCounting] to count occurrences of lines
+// instead, and [WithFilter] to skip lines by label and value.
+func Parse(r io.Reader, c *Chart, opts ...ParseOption) error {
+	return ParseContext(context.Background(), r, c, opts...)
+}
+
+// ParseContext reads data lines from r and sets their values in the chart like
+// [Parse]. Parsing stops and the context error is returned if ctx is canceled
+// before all lines are read.
+func ParseContext(ctx context.Context, r io.Reader, c *Chart, opts ...ParseOption) error {
+	p, err := newParser(opts)
+	if err != nil {
+		return err
+	}
+
+	return p.scan(ctx, r, func(line string, num int) (bool, error) {
+		if p.count {
+			if p.filter != nil && !p.filter(line, 1) {
+				return false, nil
+			}
+
+			c.Add(line, 1)
+			return true, nil
+		}
+
+		rec, err := p.parseLine(line)
+		if err != nil {
+			return false, p.skip(&LineError{Line: line, Number: num, Err: err})
+		}
+
+		if p.filter != nil && !p.filter(rec.Label, rec.Value) {
+			return false, nil
+		}
+
+		if p.sum {
+			c.Add(rec.Label, p.clampValue(rec.Value))
+		} else {
+			c.Set(rec.Label, p.clampValue(rec.Value))
+		}
+
+		return true, nil
+	})
+}
+
+// ParseValues reads numeric values from r, one value per line.
+//
+// Empty lines and comment lines are skipped like with [Parse]. Like
+// [ParseLine], values may contain currency symbols and punctuation. Lines that
+// can't be parsed are skipped. Use [WithWarningFunc] to be notified of skipped lines, or
+// [WithStrict] to fail on the first line that can't be parsed.
+func ParseValues(r io.Reader, opts ...ParseOption) ([]float64, error) {
+	p, err := newParser(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var values []float64
+
+	err = p.scan(context.Background(), r, func(line string, num int) (bool, error) {
+		value, err := p.parseValue(line)
+		if err != nil {
+			return false, p.skip(&LineError{Line: line, Number: num, Err: err})
+		}
+
+		values = append(values, p.clampValue(value))
+
+		return true, nil
+	})
+
+	return values, err
+}
+
+// ParseLine parses a data line into its float64 value and label string.
+//
+// The line is expected to have the following structure:
+//
+//	<numeric value> <label>
+//
+// The function tolerates any kind of whitespace between the value and label, as
+// well as currency symbols and punctuation. Values may have a leading sign,
+// including the Unicode minus sign (−), and be given in scientific notation,
+// like -2.5e-3.
+func ParseLine(line string, opts ...ParseOption) (float64, string, error) {
+	rec, err := ParseRecord(line, opts...)
+	if err != nil {
+		return 0, "", err
+	}
+
+	return rec.Value, rec.Label, nil
+}
+
+// ParseRecord parses a data line like [ParseLine] into a [Record].
+//
+// Values with a leading or trailing percent sign, like 45% or %45, are
+// recorded as percentages. Use [WithPercentFractions] to convert percentages
+// to fractions.
+func ParseRecord(line string, opts ...ParseOption) (Record, error) {
+	p, err := newParser(opts)
+	if err != nil {
+		return Record{}, err
+	}
+
+	return p.parseLine(line)
+}
+
+// ParseLineLabelFirst parses a data line with the label before the value into
+// its float64 value and label string.
+//
+// The line is expected to have the following structure:
+//
+//	<label> <numeric value>
+//
+// The value is taken from after the last data separator, so labels may contain
+// separators and numbers. Like [ParseLine], the function tolerates any kind of
+// whitespace between the label and value, as well as currency symbols and
+// punctuation.
+func ParseLineLabelFirst(line string, opts ...ParseOption) (float64, string, error) {
+	p, err := newParser(opts)
+	if err != nil {
+		return 0, "", err
+	}
+
+	rec, err := p.parseLineLabelFirst(line)
+	if err != nil {
+		return 0, "", err
+	}
+
+	return rec.Value, rec.Label, nil
+}
+
+func (p *parser) parseLine(line string) (Record, error) {
+	if p.firstToken {
+		return p.parseLineFirstToken(line)
+	}
+
+	seps := p.separators(line)
+	if seps == nil {
+		return Record{}, errors.New("missing data separator")
+	}
+
+	sepIdx := seps[0]
+	value := strings.TrimSpace(line[0:sepIdx[0]])
+	label := strings.TrimSpace(line[sepIdx[1]:])
+
+	return p.record(value, label)
+}
+
+// parseLineFirstToken parses a data line with the value taken from the first
+// numeric token before the first whitespace and the rest of the line as the
+// label.
+func (p *parser) parseLineFirstToken(line string) (Record, error) {
+	idx := strings.IndexFunc(line, unicode.IsSpace)
+	if idx < 0 {
+		return Record{}, errors.New("missing data separator")
+	}
+
+	token := line[:idx]
+	value := p.numericToken(token)
+
+	if strings.HasPrefix(token, "%") || strings.HasSuffix(token, "%") {
+		value += "%"
+	}
+
+	return p.record(value, strings.TrimSpace(line[idx:]))
+}
+
+// numericToken returns the first contiguous run of digits and number
+// separators in s, skipping any leading currency symbols and punctuation. The
+// run keeps a sign before it and an exponent after it, like in -2.5e-3.
+func (p *parser) numericToken(s string) string {
+	start := strings.IndexFunc(s, unicode.IsDigit)
+	if start < 0 {
+		return ""
+	}
+
+	sign := leadingSign(s)
+
+	if start > 0 && s[start-1] == '.' {
+		start-- // Keep the decimal point of values like .5.
+	}
+
+	end := start
+	for end < len(s) {
+		r, size := utf8.DecodeRuneInString(s[end:])
+		if !unicode.IsDigit(r) && r != '.' && r != ',' && r != p.thousandsSep && r != p.decimalSep {
+			break
+		}
+
+		end += size
+	}
+
+	if m := expRE.FindStringSubmatchIndex(s[end-1:]); m != nil && m[0] == 0 {
+		end += m[3] - 1
+	}
+
+	return sign + s[start:end]
+}
+
+func (p *parser) parseLineLabelFirst(line string) (Record, error) {
+	line = strings.TrimSpace(line)
+
+	seps := p.separators(line)
+	if seps == nil {
+		return Record{}, errors.New("missing data separator")
+	}
+
+	sepIdx := seps[len(seps)-1]
+	value := strings.TrimSpace(line[sepIdx[1]:])
+	label := strings.TrimRightFunc(line[0:sepIdx[0]], func(r rune) bool {
+		return p.sepRE.MatchString(string(r))
+	})
+
+	return p.record(value, label)
+}
+
+// record returns a [Record] from the value and label parts of a data line.
+func (p *parser) record(value, label string) (Record, error) {
+	if label == "" {
+		return Record{}, errors.New("missing label")
+	}
+
+	num, err := p.parseValue(value)
+	if err != nil {
+		return Record{}, err
+	}
+
+	percent := strings.HasPrefix(value, "%") || strings.HasSuffix(value, "%")
+	if percent && p.percentFractions {
+		num /= 100
+	}
+
+	return Record{Label: label, Value: num, Percent: percent}, nil
+}
+
+// scan reads lines from r and calls fn with each trimmed line and its line
+// number, skipping empty lines and comments. fn reports whether the line was
+// parsed successfully.
+//
+// Scanning stops when the configured limit of parsed lines is reached, when fn
+// returns an error, or when ctx is canceled.
+func (p *parser) scan(ctx context.Context, r io.Reader, fn func(line string, num int) (bool, error)) error {
+	scanner := bufio.NewScanner(r)
+
+	for n, num := 0, 0; (p.limit <= 0 || n < p.limit) && scanner.Scan(); {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("reading data: %w", err)
+		}
+
+		num++
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || p.isComment(line) {
+			continue
+		}
+
+		ok, err := fn(line, num)
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			n++
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading data: %w", err)
+	}
+
+	return nil
+}
+
+// clampValue returns value clamped into the configured range, or value as-is
+// if no range is configured.
+func (p *parser) clampValue(value float64) float64 {
+	if p.clamp == nil {
+		return value
+	}
+
+	return min(max(value, p.clamp[0]), p.clamp[1])
+}
+
+// isComment reports whether line is a comment.
+func (p *parser) isComment(line string) bool {
+	return p.comment != "" && strings.HasPrefix(line, p.comment)
+}
+
+// skip handles a line that can't be parsed. Returns err in strict mode,
+// otherwise calls the warning function, if any, and returns nil.
+func (p *parser) skip(err *LineError) error {
+	if p.strict {
+		return err
+	}
+
+	if p.warnFn != nil {
+		p.warnFn(err)
+	}
+
+	return nil
+}
+
+// separators returns the indexes of data separators in line, excluding number
+// separators that are part of a value.
+func (p *parser) separators(line string) [][]int {
+	var seps [][]int
+
+	for _, idx := range p.sepRE.FindAllStringIndex(line, -1) {
+		if p.isNumberSep(line, idx) {
+			continue
+		}
+
+		seps = append(seps, idx)
+	}
+
+	return seps
+}
+
+// isNumberSep reports whether the separator at idx in line is a configured
+// thousands or decimal separator between two digits.
+func (p *parser) isNumberSep(line string, idx []int) bool {
+	if p.thousandsSep == 0 && p.decimalSep == '.' {
+		return false
+	}
+
+	r, _ := utf8.DecodeRuneInString(line[idx[0]:])
+	if r != p.thousandsSep && r != p.decimalSep {
+		return false
+	}
+
+	before, _ := utf8.DecodeLastRuneInString(line[:idx[0]])
+	after, _ := utf8.DecodeRuneInString(line[idx[1]:])
+
+	return unicode.IsDigit(before) && unicode.IsDigit(after)
+}
+
+// parseValue parses a numeric value, ignoring any characters that are not
+// digits or decimal separators.
+func (p *parser) parseValue(value string) (float64, error) {
+	if p.thousandsSep != 0 {
+		value = strings.ReplaceAll(value, string(p.thousandsSep), "")
+	}
+
+	if p.decimalSep != '.' {
+		value = strings.ReplaceAll(value, ".", "")
+		value = strings.ReplaceAll(value, string(p.decimalSep), ".")
+	}
+
+	var exp string
+	if m := expRE.FindStringSubmatchIndex(value); m != nil {
+		exp = value[m[2]:m[3]]
+		value = value[:m[2]]
+	}
+
+	sign := leadingSign(value)
+
+	value = strings.TrimSuffix(strings.Join(floatRE.FindAllString(value, -1), ""), ".")
+	if value == "" {
+		return 0, errors.New("missing value")
+	}
+
+	value = sign + value + exp
+
+	count, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parsing %q as float64: %w", value, err)
+	}
+
+	return count, nil
+}
+
+// leadingSign returns the first plus or minus sign before the first digit in
+// s, or an empty string if there is none. The Unicode minus sign (−) used in
+// typeset numbers is returned as a hyphen-minus.
+func leadingSign(s string) string {
+	i := strings.IndexAny(s, "+-\u2212")
+	if i < 0 || i > strings.IndexFunc(s, unicode.IsDigit) {
+		return ""
+	}
+
+	if s[i] == '+' {
+		return "+"
+	}
+
+	return "-"
+}
+
+// WithNumberSeparators configures parsing of values with a thousands separator
+// and a decimal separator, e.g. '.' and ',' for values like 1.234,56.
+//
+// Configured separators are not treated as data separators when they appear
+// between two digits. Use 0 as the thousands separator for values without
+// digit grouping.
+func WithNumberSeparators(thousands, decimal rune) ParseOption {
+	return func(p *parser) error {
+		if decimal == 0 {
+			return errors.New("decimal separator must be set")
+		}
+
+		if thousands == decimal {
+			return errors.New("thousands and decimal separators must be different")
+		}
+
+		p.thousandsSep = thousands
+		p.decimalSep = decimal
+		return nil
+	}
+}
+
+// WithPercentFractions configures parsing to convert values given as
+// percentages to fractions, e.g. 45% to 0.45.
+func WithPercentFractions(enable bool) ParseOption {
+	return func(p *parser) error {
+		p.percentFractions = enable
+		return nil
+	}
+}
+
+// WithWarningFunc configures a function to call with details of data lines
+// that are skipped because they can't be parsed.
+func WithWarningFunc(fn func(*LineError)) ParseOption {
+	return func(p *parser) error {
+		p.warnFn = fn
+		return nil
+	}
+}
+
+// WithStrict configures [Parse] and [ParseValues] to stop and return a
+// [*LineError] for the first line that can't be parsed, instead of skipping
+// it.
+func WithStrict(enable bool) ParseOption {
+	return func(p *parser) error {
+		p.strict = enable
+		return nil
+	}
+}
+
+// WithSeparator configures parsing to only separate values from labels at
+// sep, e.g. '\t' for tab-separated data where labels contain characters that
+// are separators by default, like colons in URLs and timestamps.
+func WithSeparator(sep rune) ParseOption {
+	return func(p *parser) error {
+		if sep == utf8.RuneError || unicode.IsDigit(sep) {
+			return fmt.Errorf("invalid separator %q", sep)
+		}
+
+		p.sepRE = regexp.MustCompile(regexp.QuoteMeta(string(sep)))
+		return nil
+	}
+}
+
+// WithSeparatorPattern configures parsing to only separate values from labels
+// at matches of re, e.g. \t+ for values and labels separated by one or more
+// tabs. By default, whitespace and the symbols , ; : | and # are separators.
+func WithSeparatorPattern(re *regexp.Regexp) ParseOption {
+	return func(p *parser) error {
+		if re == nil {
+			return errors.New("separator pattern must not be nil")
+		}
+
+		p.sepRE = re
+		return nil
+	}
+}
+
+// WithFirstToken configures parsing to take the value from the first
+// contiguous numeric token before the first whitespace of a line, and the
+// rest of the line after the whitespace as the label.
+//
+// By default, values are separated from labels at the first separator, so a
+// time-like value in a line like 1:30 duration is parsed as the value 1 with
+// the label 30 duration. With this option, the line is parsed as the value 1
+// with the label duration, and 3/4 done as the value 3 with the label done.
+func WithFirstToken(enable bool) ParseOption {
+	return func(p *parser) error {
+		p.firstToken = enable
+		return nil
+	}
+}
+
+// WithCommentPrefix configures [Parse] and [ParseValues] to skip lines
+// starting with prefix, e.g. // or ;, instead of [DefaultCommentPrefix]. An
+// empty prefix disables skipping of comment lines.
+//
+// Comments are only recognized at the start of lines, so a prefix that is
+// also a data separator, like # or ;, still separates values from labels
+// elsewhere in a line. As the value is separated from the label at the first
+// separator, a line like 42 #1 priority is parsed as the value 42 with the
+// label #1 priority.
+func WithCommentPrefix(prefix string) ParseOption {
+	return func(p *parser) error {
+		p.comment = prefix
+		return nil
+	}
+}
+
+// WithCounting configures [Parse] to count occurrences of each distinct line
+// instead of parsing lines as values and labels.
+func WithCounting(enable bool) ParseOption {
+	return func(p *parser) error {
+		p.count = enable
+		return nil
+	}
+}
+
+// WithSumming configures [Parse] to sum the values of lines with the same
+// label instead of keeping the value of the last line.
+func WithSumming(enable bool) ParseOption {
+	return func(p *parser) error {
+		p.sum = enable
+		return nil
+	}
+}
+
+// WithFilter configures [Parse] to skip lines for which fn returns false.
+// When counting occurrences with [WithCounting], fn is called with each line
+// as the label and a value of 1. Skipped lines don't count towards the limit
+// configured with [WithLimit].
+func WithFilter(fn func(label string, value float64) bool) ParseOption {
+	return func(p *parser) error {
+		p.filter = fn
+		return nil
+	}
+}
+
+// WithClamp configures [Parse] and [ParseValues] to clamp each parsed value
+// into the range [lo, hi]. Clamping happens when values are stored, before
+// rendering, so [Chart.MaxValue] and [Chart.MinValue] reflect the clamped
+// values. Counted occurrences with [WithCounting] are not clamped.
+//
+// Returns an error if lo is greater than hi.
+func WithClamp(lo, hi float64) ParseOption {
+	return func(p *parser) error {
+		if lo > hi {
+			return errors.New("clamp minimum must not be greater than maximum")
+		}
+
+		p.clamp = &[2]float64{lo, hi}
+
+		return nil
+	}
+}
+
+// WithLimit configures [Parse] and [ParseValues] to stop reading after n lines
+// have been parsed successfully. Skipped lines don't count towards the limit.
+// When counting occurrences with [WithCounting], every counted line counts
+// towards the limit.
+//
+// A limit of zero means no limit. Returns an error if n is negative.
+func WithLimit(n int) ParseOption {
+	return func(p *parser) error {
+		if n < 0 {
+			return errors.New("limit must be a non-negative integer")
+		}
+
+		p.limit = n
+
+		return nil
+	}
+}
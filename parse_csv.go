@@ -0,0 +1,112 @@
+package chart
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// CSVOption configures [ParseCSV].
+type CSVOption func(*csvParser) error
+
+type csvParser struct {
+	comma    rune
+	header   bool
+	labelCol int
+	valueCol int
+}
+
+// ParseCSV reads CSV records from r and sets their values in the chart.
+//
+// By default, records are expected to be comma-separated with the label in
+// the first column and the value in the second. Values are parsed like in
+// [ParseLine], so they may contain currency symbols and punctuation.
+//
+// Unlike [Parse], an error is returned if a record can't be parsed.
+func ParseCSV(r io.Reader, c *Chart, opts ...CSVOption) error {
+	p := &csvParser{comma: ',', valueCol: 1}
+
+	for i, opt := range opts {
+		if err := opt(p); err != nil {
+			return fmt.Errorf("applying option #%d: %w", i+1, err)
+		}
+	}
+
+	cr := csv.NewReader(r)
+	cr.Comma = p.comma
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	if p.header {
+		if _, err := cr.Read(); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("reading CSV header: %w", err)
+		}
+	}
+
+	vp := &parser{decimalSep: '.'}
+
+	for {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("reading CSV record: %w", err)
+		}
+
+		line, _ := cr.FieldPos(0)
+
+		if p.labelCol >= len(rec) || p.valueCol >= len(rec) {
+			return fmt.Errorf("CSV record on line %d: expected at least %d fields, got %d",
+				line, max(p.labelCol, p.valueCol)+1, len(rec))
+		}
+
+		value, err := vp.parseValue(rec[p.valueCol])
+		if err != nil {
+			return fmt.Errorf("CSV record on line %d: %w", line, err)
+		}
+
+		c.Set(rec[p.labelCol], value)
+	}
+}
+
+// WithCSVComma configures [ParseCSV] to use a field delimiter other than
+// comma.
+func WithCSVComma(comma rune) CSVOption {
+	return func(p *csvParser) error {
+		if comma == '"' || comma == '\r' || comma == '\n' {
+			return fmt.Errorf("invalid CSV delimiter: %q", comma)
+		}
+
+		p.comma = comma
+		return nil
+	}
+}
+
+// WithCSVHeader configures [ParseCSV] to skip the first record as a header.
+func WithCSVHeader(enable bool) CSVOption {
+	return func(p *csvParser) error {
+		p.header = enable
+		return nil
+	}
+}
+
+// WithCSVColumns configures [ParseCSV] to read labels and values from the
+// columns at the given zero-based indexes.
+func WithCSVColumns(label, value int) CSVOption {
+	return func(p *csvParser) error {
+		if label < 0 || value < 0 {
+			return errors.New("column indexes must not be negative")
+		}
+
+		if label == value {
+			return errors.New("label and value columns must be different")
+		}
+
+		p.labelCol = label
+		p.valueCol = value
+		return nil
+	}
+}
@@ -0,0 +1,166 @@
+package chart_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/michenriksen/chart"
+)
+
+func TestParseCSV(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		opts       []chart.CSVOption
+		wantLabels []string
+		wantValues []float64
+	}{
+		{
+			name:       "defaults",
+			input:      "apples,20\noranges, 10.5\npears,-3\n",
+			wantLabels: []string{"apples", "oranges", "pears"},
+			wantValues: []float64{20, 10.5, -3},
+		},
+		{
+			name:       "quoted fields",
+			input:      "\"apples, red\",20\noranges,\"1,000\"\n\"pears \"\"green\"\"\",\"$15\"\n",
+			wantLabels: []string{"apples, red", "oranges", `pears "green"`},
+			wantValues: []float64{20, 1000, 15},
+		},
+		{
+			name:       "comma",
+			input:      "apples, red;20\noranges;1,000\n",
+			opts:       []chart.CSVOption{chart.WithCSVComma(';')},
+			wantLabels: []string{"apples, red", "oranges"},
+			wantValues: []float64{20, 1000},
+		},
+		{
+			name:       "tab",
+			input:      "apples\t20\noranges\t10\n",
+			opts:       []chart.CSVOption{chart.WithCSVComma('\t')},
+			wantLabels: []string{"apples", "oranges"},
+			wantValues: []float64{20, 10},
+		},
+		{
+			name:       "header",
+			input:      "fruit,amount\napples,20\noranges,10\n",
+			opts:       []chart.CSVOption{chart.WithCSVHeader(true)},
+			wantLabels: []string{"apples", "oranges"},
+			wantValues: []float64{20, 10},
+		},
+		{
+			name:       "header only",
+			input:      "fruit,amount\n",
+			opts:       []chart.CSVOption{chart.WithCSVHeader(true)},
+			wantLabels: []string{},
+			wantValues: []float64{},
+		},
+		{
+			name:       "columns",
+			input:      "2024-01-01,20,apples\n2024-01-02,10,oranges,extra\n",
+			opts:       []chart.CSVOption{chart.WithCSVColumns(2, 1)},
+			wantLabels: []string{"apples", "oranges"},
+			wantValues: []float64{20, 10},
+		},
+		{
+			name:  "all options",
+			input: "amount;fruit\n20;apples\n10;oranges\n",
+			opts: []chart.CSVOption{
+				chart.WithCSVComma(';'),
+				chart.WithCSVHeader(true),
+				chart.WithCSVColumns(1, 0),
+			},
+			wantLabels: []string{"apples", "oranges"},
+			wantValues: []float64{20, 10},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := chart.New(chart.WithPrecision(1))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := chart.ParseCSV(strings.NewReader(tt.input), c, tt.opts...); err != nil {
+				t.Fatal(err)
+			}
+
+			labels, values := c.Snapshot()
+			if !slices.Equal(labels, tt.wantLabels) {
+				t.Errorf("expected labels %q, got %q", tt.wantLabels, labels)
+			}
+
+			if !slices.Equal(values, tt.wantValues) {
+				t.Errorf("expected values %v, got %v", tt.wantValues, values)
+			}
+		})
+	}
+}
+
+func TestParseCSVErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		opts    []chart.CSVOption
+		wantErr string
+	}{
+		{
+			name:    "too few fields",
+			input:   "apples,20\noranges\n",
+			wantErr: "CSV record on line 2: expected at least 2 fields, got 1",
+		},
+		{
+			name:    "column out of range",
+			input:   "apples,20\n",
+			opts:    []chart.CSVOption{chart.WithCSVColumns(0, 3)},
+			wantErr: "CSV record on line 1: expected at least 4 fields, got 2",
+		},
+		{
+			name:    "unparsable value",
+			input:   "apples,20\noranges,many\n",
+			wantErr: "CSV record on line 2: missing value",
+		},
+		{
+			name:    "unparsable value after header",
+			input:   "fruit,amount\napples,\n",
+			opts:    []chart.CSVOption{chart.WithCSVHeader(true)},
+			wantErr: "CSV record on line 2: missing value",
+		},
+		{
+			name:    "bare quote",
+			input:   "app\"les,20\n",
+			wantErr: "reading CSV record",
+		},
+		{
+			name:    "invalid comma",
+			opts:    []chart.CSVOption{chart.WithCSVComma('"')},
+			wantErr: "applying option #1: invalid CSV delimiter",
+		},
+		{
+			name:    "negative column",
+			opts:    []chart.CSVOption{chart.WithCSVHeader(true), chart.WithCSVColumns(-1, 1)},
+			wantErr: "applying option #2: column indexes must not be negative",
+		},
+		{
+			name:    "same columns",
+			opts:    []chart.CSVOption{chart.WithCSVColumns(1, 1)},
+			wantErr: "label and value columns must be different",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := chart.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = chart.ParseCSV(strings.NewReader(tt.input), c, tt.opts...)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package chart
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// jsonRecord is a label and value pair in a JSON array.
+type jsonRecord struct {
+	Label string   `json:"label"`
+	Value *float64 `json:"value"`
+}
+
+// ParseJSON reads JSON data from r and sets its values in the chart.
+//
+// The data can either be an object mapping labels to values:
+//
+//	{"apples": 10, "oranges": 20}
+//
+// or an array of objects with label and value fields:
+//
+//	[{"label": "apples", "value": 10}, {"label": "oranges", "value": 20}]
+//
+// Labels are added in the order they appear in the data for both forms, but
+// note that JSON objects are unordered by definition, so tools producing them
+// may not preserve any particular order. Use the array form if order matters.
+func ParseJSON(r io.Reader, c *Chart) error {
+	dec := json.NewDecoder(r)
+
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("decoding JSON: %w", err)
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		err = parseJSONObject(dec, c)
+	case json.Delim('['):
+		err = parseJSONArray(dec, c)
+	default:
+		err = errors.New("expected object or array")
+	}
+
+	if err != nil {
+		return fmt.Errorf("decoding JSON: %w", err)
+	}
+
+	return nil
+}
+
+func parseJSONObject(dec *json.Decoder, c *Chart) error {
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return fmt.Errorf("reading label: %w", err)
+		}
+
+		label, _ := tok.(string)
+
+		var value float64
+		if err := dec.Decode(&value); err != nil {
+			return fmt.Errorf("value for label %q: %w", label, err)
+		}
+
+		c.Set(label, value)
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("reading end of object: %w", err)
+	}
+
+	return nil
+}
+
+func parseJSONArray(dec *json.Decoder, c *Chart) error {
+	for i := 0; dec.More(); i++ {
+		var rec jsonRecord
+		if err := dec.Decode(&rec); err != nil {
+			return fmt.Errorf("element #%d: %w", i+1, err)
+		}
+
+		if rec.Value == nil {
+			return fmt.Errorf("element #%d: missing value", i+1)
+		}
+
+		c.Set(rec.Label, *rec.Value)
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("reading end of array: %w", err)
+	}
+
+	return nil
+}
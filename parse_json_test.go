@@ -0,0 +1,96 @@
+package chart_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/michenriksen/chart"
+)
+
+func TestParseJSON(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantLabels []string
+		wantValues []float64
+	}{
+		{
+			name:       "object",
+			input:      `{"oranges": 20, "apples": 10.5, "pears": -3}`,
+			wantLabels: []string{"oranges", "apples", "pears"},
+			wantValues: []float64{20, 10.5, -3},
+		},
+		{
+			name:       "array",
+			input:      `[{"label": "oranges", "value": 20}, {"value": 10.5, "label": "apples"}, {"label": "pears", "value": -3}]`,
+			wantLabels: []string{"oranges", "apples", "pears"},
+			wantValues: []float64{20, 10.5, -3},
+		},
+		{
+			name:       "empty object",
+			input:      `{}`,
+			wantLabels: []string{},
+			wantValues: []float64{},
+		},
+		{
+			name:       "empty array",
+			input:      ` [ ] `,
+			wantLabels: []string{},
+			wantValues: []float64{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := chart.New(chart.WithPrecision(1))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := chart.ParseJSON(strings.NewReader(tt.input), c); err != nil {
+				t.Fatal(err)
+			}
+
+			labels, values := c.Snapshot()
+			if !slices.Equal(labels, tt.wantLabels) {
+				t.Errorf("expected labels %v, got %v", tt.wantLabels, labels)
+			}
+
+			if !slices.Equal(values, tt.wantValues) {
+				t.Errorf("expected values %v, got %v", tt.wantValues, values)
+			}
+		})
+	}
+}
+
+func TestParseJSONErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{name: "empty input", input: ``, wantErr: "decoding JSON: EOF"},
+		{name: "malformed", input: `{"a": 1,`, wantErr: "decoding JSON:"},
+		{name: "unterminated array", input: `[{"label": "a", "value": 1}`, wantErr: "unexpected end of JSON input"},
+		{name: "scalar", input: `42`, wantErr: "expected object or array"},
+		{name: "string value", input: `{"a": "ten"}`, wantErr: `value for label "a"`},
+		{name: "null element", input: `[null]`, wantErr: "element #1: missing value"},
+		{name: "missing value", input: `[{"label": "a", "value": 1}, {"label": "b"}]`, wantErr: "element #2: missing value"},
+		{name: "string element value", input: `[{"label": "a", "value": "1"}]`, wantErr: "element #1:"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := chart.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = chart.ParseJSON(strings.NewReader(tt.input), c)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package simple
+
+import (
+	"io"
+	"testing"
+)
+
+// ForceTerminal makes renderers treat all writers as terminals until the test
+// has finished.
+func ForceTerminal(t *testing.T) {
+	t.Helper()
+
+	orig := isTerminal
+	isTerminal = func(io.Writer) bool { return true }
+
+	t.Setenv("NO_COLOR", "")
+	t.Cleanup(func() { isTerminal = orig })
+}
@@ -1,36 +1,121 @@
 package simple
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"math"
+	"os"
 	"strings"
 	"unicode/utf8"
 
 	"github.com/michenriksen/chart"
+	"github.com/michenriksen/chart/internal/numfmt"
+	"golang.org/x/text/width"
 )
 
 const smallTick = '▏'
 
+// partialTicks are the ticks for drawing bar remainders in eighths, from one
+// eighth to seven eighths.
+var partialTicks = []rune("▏▎▍▌▋▊▉")
+
+// ASCII ticks used instead of Unicode block elements when ASCII mode is
+// enabled.
+const (
+	asciiTick      = '#'
+	asciiSmallTick = '.'
+)
+
+// asciiPartialTicks are the ASCII ticks for drawing bar remainders in eighths.
+var asciiPartialTicks = []rune("...||||")
+
+// defaultColorPalette is the default palette of 256-color codes for coloring
+// bars.
+var defaultColorPalette = []string{"39", "208", "77", "170", "220", "81", "203", "141"}
+
+const colorReset = "\033[0m"
+
+// Align is a label alignment.
+type Align int
+
+// Label alignments.
+const (
+	AlignRight Align = iota // Align labels to the right.
+	AlignLeft               // Align labels to the left.
+)
+
+// Orientation is the direction bars are drawn in.
+type Orientation int
+
+// Bar orientations.
+const (
+	Horizontal Orientation = iota // Draw bars as horizontal rows.
+	Vertical                      // Draw bars as vertical columns.
+)
+
+// ScaleMode is a scale for bar lengths.
+type ScaleMode int
+
+// Scale modes.
+const (
+	ScaleLinear ScaleMode = iota // Scale bars linearly.
+	ScaleLog                     // Scale bars logarithmically.
+	ScaleSymlog                  // Scale bars symmetric logarithmically.
+)
+
 // Default option values.
 const (
 	DefaultTick           = '▇'
 	DefaultMaxLength      = 80
 	DefaultMaxLabelLength = 20
-	DefaultScale          = false
+	DefaultScaleMode      = ScaleLinear
+	DefaultLabelAlign     = AlignRight
+	DefaultOrientation    = Horizontal
+	DefaultMaxHeight      = 10
 )
 
+// DefaultScale is the default for logarithmic scaling.
+//
+// Deprecated: Use [DefaultScaleMode].
+const DefaultScale = false
+
 // Renderer renders a [chart.Chart] with simple characters and symbols suitable
 // for display in terminals and text files.
 type Renderer struct {
 	maxLen          int
 	maxLabelLen     int
-	scale           bool
+	labelAlign      Align
+	orientation     Orientation
+	maxHeight       int
+	scaleMode       ScaleMode
 	tick            rune
+	partial         bool
+	color           bool
+	palette         []string
+	thresholds      []Threshold
+	valueFmt        func(float64) string
+	grouping        bool
+	locale          numfmt.Locale
+	unit            string
+	percentages     bool
+	ascii           bool
+	minGlyph        *rune
+	axis            bool
+	footer          bool
+	footerFn        func(*chart.Chart) string
+	sum             float64
+	count           int
+	colorize        bool
+	highlightMax    bool
+	maxLabel        string
 	longestLabelLen int
 	longestValLen   int
 	maxVal          float64
+	maxAbs          float64
+	prec            int
 	barLen          int
 }
 
@@ -41,10 +126,17 @@ func NewRenderer(opts ...RendererOption) (*Renderer, error) {
 	r := &Renderer{
 		maxLen:      DefaultMaxLength,
 		maxLabelLen: DefaultMaxLabelLength,
-		scale:       DefaultScale,
+		labelAlign:  DefaultLabelAlign,
+		orientation: DefaultOrientation,
+		maxHeight:   DefaultMaxHeight,
+		scaleMode:   DefaultScaleMode,
 		tick:        DefaultTick,
+		palette:     defaultColorPalette,
+		locale:      numfmt.Locales[numfmt.DefaultLocale],
 	}
 
+	r.footerFn = r.defaultFooter
+
 	for i, opt := range opts {
 		if err := opt(r); err != nil {
 			return nil, fmt.Errorf("applying option #%d: %w", i+1, err)
@@ -56,32 +148,61 @@ func NewRenderer(opts ...RendererOption) (*Renderer, error) {
 
 // Render renders chart to out writer.
 func (r *Renderer) Render(c *chart.Chart, out io.Writer) (int, error) {
-	r.maxVal = c.MaxValue()
-	r.longestLabelLen = min(len(c.MaxLabel()), r.maxLabelLen)
-	r.longestValLen = len(r.value(r.maxVal))
-	r.barLen = r.maxLen - r.longestLabelLen - r.longestValLen - 2
-
-	written := 0
+	return r.RenderContext(context.Background(), c, out)
+}
 
-	for _, label := range c.Labels() {
-		value, err := c.Value(label)
-		if err != nil {
-			return written, fmt.Errorf("getting value for %q label: %w", label, err)
+// RenderContext renders chart to out writer like [Renderer.Render]. Rendering
+// stops and nothing is written if ctx is canceled before all bars are drawn.
+func (r *Renderer) RenderContext(ctx context.Context, c *chart.Chart, out io.Writer) (int, error) {
+	labels, values := c.Snapshot()
+
+	r.prec = c.Precision()
+	r.maxVal, r.maxAbs, r.sum, r.count = 0, 0, 0, len(values)
+	r.maxLabel = ""
+
+	// Derive statistics from the snapshot rather than the chart, so they are
+	// consistent with the drawn values if the chart is modified concurrently.
+	for i, value := range values {
+		if i == 0 || value > r.maxVal {
+			r.maxVal = value
+			r.maxLabel = labels[i]
 		}
 
-		n, err := r.write(label, value, out)
-		if err != nil {
-			return written, fmt.Errorf("writing bar for label %q (value %g): %w", label, value, err)
+		r.maxAbs = max(r.maxAbs, math.Abs(value))
+		r.sum += value
+	}
+
+	r.longestLabelLen = min(longestWidth(labels), r.maxLabelLen)
+	r.longestValLen = r.longestValueLen(values)
+	r.barLen = max(r.maxLen-r.longestLabelLen-r.longestValLen-2, 0)
+	r.colorize = r.color && isTerminal(out) && os.Getenv("NO_COLOR") == ""
+
+	buf := new(bytes.Buffer)
+
+	if r.orientation == Vertical {
+		if err := r.writeVertical(ctx, labels, values, buf); err != nil {
+			return 0, err
 		}
+	} else {
+		if r.axis && r.maxVal > 0 && r.barLen > 0 {
+			fmt.Fprintln(buf, r.axisLine())
+		}
+
+		for i, label := range labels {
+			if err := ctx.Err(); err != nil {
+				return 0, fmt.Errorf("rendering chart: %w", err)
+			}
 
-		written += n
+			value := values[i]
+			r.write(label, value, r.barColor(i, value), buf)
+		}
 	}
 
-	return written, nil
-}
+	if r.footer {
+		fmt.Fprintln(buf, r.footerFn(c))
+	}
 
-func (r *Renderer) write(label string, value float64, out io.Writer) (int, error) {
-	n, err := fmt.Fprintf(out, "%s %s %s\n", r.label(label), r.bar(value), r.value(value))
+	n, err := out.Write(buf.Bytes())
 	if err != nil {
 		return n, fmt.Errorf("writing to out: %w", err)
 	}
@@ -89,36 +210,279 @@ func (r *Renderer) write(label string, value float64, out io.Writer) (int, error
 	return n, nil
 }
 
+// defaultFooter returns a footer with the sum and number of drawn labels.
+func (r *Renderer) defaultFooter(*chart.Chart) string {
+	labels := "labels"
+	if r.count == 1 {
+		labels = "label"
+	}
+
+	return fmt.Sprintf("Total: %s (%d %s)", r.formatValue(r.sum), r.count, labels)
+}
+
+func (r *Renderer) write(label string, value float64, color string, buf *bytes.Buffer) {
+	bar := r.paint(r.bar(value), color, r.isHighlighted(label))
+
+	fmt.Fprintf(buf, "%s %s %s\n", r.label(label), bar, r.value(value))
+}
+
+// paint wraps s in escape codes for drawing it in color and in bold if
+// highlighted. Returns s as-is if colors are not drawn.
+func (r *Renderer) paint(s, color string, highlight bool) string {
+	if !r.colorize {
+		return s
+	}
+
+	var codes []string
+	if highlight {
+		codes = append(codes, "1")
+	}
+
+	if color != "" {
+		codes = append(codes, "38;5;"+color)
+	}
+
+	if len(codes) == 0 {
+		return s
+	}
+
+	return "\033[" + strings.Join(codes, ";") + "m" + s + colorReset
+}
+
+// isHighlighted reports whether the bar for label should be highlighted.
+func (r *Renderer) isHighlighted(label string) bool {
+	return r.highlightMax && label == r.maxLabel
+}
+
+// barColor returns the color code for the bar at index i with the given value.
+// Returns an empty string if the bar should use the default terminal color.
+func (r *Renderer) barColor(i int, value float64) string {
+	if len(r.thresholds) == 0 {
+		return r.palette[i%len(r.palette)]
+	}
+
+	for _, t := range r.thresholds {
+		if value >= t.Min {
+			return t.Color
+		}
+	}
+
+	return ""
+}
+
 func (r *Renderer) bar(value float64) string {
-	length := value / r.maxVal * float64(r.barLen)
-	if r.scale {
-		length = math.Log10(value+1) / math.Log10(float64(r.maxVal)+1) * float64(r.barLen)
+	length := r.fraction(value) * float64(r.barLen)
+
+	tick, small, partials := r.ticks()
+
+	if r.partial && partials != nil {
+		return partialBar(length, tick, small, partials)
 	}
+
 	length = math.Round(length)
 
 	if length == 0 {
-		if r.tick == DefaultTick {
-			return string(smallTick)
+		if small != 0 {
+			return string(small)
 		}
 
 		return ""
 	}
 
-	return strings.Repeat(string(r.tick), int(length))
+	return strings.Repeat(string(tick), int(length))
+}
+
+// fraction returns the fraction of the bar region covered by the bar for value
+// according to the scale mode.
+//
+// Bars can't extend below zero, so negative values are drawn as empty bars,
+// except in symmetric logarithmic mode where bars are drawn for the magnitude
+// of values relative to the largest magnitude.
+func (r *Renderer) fraction(value float64) float64 {
+	switch r.scaleMode {
+	case ScaleSymlog:
+		if r.maxAbs == 0 {
+			return 0
+		}
+
+		return math.Abs(symlog(value)) / symlog(r.maxAbs)
+	case ScaleLog:
+		if r.maxVal <= 0 {
+			return 0
+		}
+
+		// Logarithms are undefined for values of -1 and below, so negative
+		// values are clamped to zero.
+		return math.Log10(max(value, 0)+1) / math.Log10(r.maxVal+1)
+	default:
+		if r.maxVal <= 0 {
+			return 0
+		}
+
+		return max(value, 0) / r.maxVal
+	}
+}
+
+// symlog returns the symmetric logarithm of x, which maps zero to zero and
+// negative values symmetrically to positive values.
+func symlog(x float64) float64 {
+	if x < 0 {
+		return -math.Log10(1 - x)
+	}
+
+	return math.Log10(1 + x)
+}
+
+// ticks returns the tick for drawing bars, the glyph for zero-length bars, and
+// the partial ticks for drawing bar remainders. The zero-length bar glyph is 0
+// if nothing should be drawn, and partial ticks are nil if not supported by the
+// configured tick.
+func (r *Renderer) ticks() (tick, small rune, partials []rune) {
+	switch {
+	case r.ascii:
+		tick, small, partials = asciiTick, asciiSmallTick, asciiPartialTicks
+	case r.tick == DefaultTick:
+		tick, small, partials = r.tick, smallTick, partialTicks
+	default:
+		tick, small = r.tick, smallTick
+	}
+
+	if r.minGlyph != nil {
+		small = *r.minGlyph
+	}
+
+	return tick, small, partials
+}
+
+// partialBar returns a bar drawn with full ticks and a partial tick for the
+// remainder, rounded to the nearest eighth.
+func partialBar(length float64, tick, small rune, partials []rune) string {
+	full := math.Floor(length)
+	eighths := int(math.Round((length - full) * 8))
+
+	if eighths == 8 {
+		full++
+		eighths = 0
+	}
+
+	if full == 0 && eighths == 0 {
+		if small == 0 {
+			return ""
+		}
+
+		return string(small)
+	}
+
+	bar := strings.Repeat(string(tick), int(full))
+	if eighths > 0 {
+		bar += string(partials[eighths-1])
+	}
+
+	return bar
 }
 
 func (r *Renderer) label(label string) string {
-	if len(label) > r.maxLabelLen {
+	if displayWidth(label) > r.maxLabelLen {
 		label = truncate(label, r.maxLabelLen)
 	}
 
-	format := fmt.Sprintf("%%%ds", min(r.longestLabelLen, r.maxLabelLen))
+	// Pad by display width, as fmt pads by rune count which misaligns labels
+	// with wide runes.
+	padding := strings.Repeat(" ", max(r.longestLabelLen-displayWidth(label), 0))
+	if r.labelAlign == AlignLeft {
+		return label + padding
+	}
 
-	return fmt.Sprintf(format, label)
+	return padding + label
 }
 
-func (*Renderer) value(value float64) string {
-	return fmt.Sprintf("%g", value)
+func (r *Renderer) value(value float64) string {
+	s := r.formatValue(value)
+
+	if r.unit != "" {
+		s += " " + r.unit
+	}
+
+	if r.percentages && r.sum != 0 {
+		s += " (" + numfmt.Format(value/r.sum*100, 1, r.numberLocale()) + "%)"
+	}
+
+	return s
+}
+
+// formatValue formats a value without unit and percentage.
+func (r *Renderer) formatValue(value float64) string {
+	if r.valueFmt != nil {
+		return r.valueFmt(value)
+	}
+
+	return numfmt.Format(value, r.prec, r.numberLocale())
+}
+
+// numberLocale returns the locale for formatting numbers, without a group
+// separator if grouping is disabled.
+func (r *Renderer) numberLocale() numfmt.Locale {
+	loc := r.locale
+	if !r.grouping {
+		loc.Group = ""
+	}
+
+	return loc
+}
+
+// axisLine returns a line with values for the start, middle, and end of the
+// bar region, aligned with the bars.
+func (r *Renderer) axisLine() string {
+	line := []rune(strings.Repeat(" ", r.longestLabelLen+1+r.barLen))
+	offset := r.longestLabelLen + 1
+
+	// place writes s at pos unless it would overflow the bar region or touch
+	// an already placed value.
+	place := func(s string, pos int) {
+		text := []rune(s)
+		if pos < offset || pos+len(text) > len(line) {
+			return
+		}
+
+		if strings.TrimSpace(string(line[max(pos-1, 0):min(pos+len(text)+1, len(line))])) != "" {
+			return
+		}
+
+		copy(line[pos:], text)
+	}
+
+	start := r.formatValue(0)
+	end := r.formatValue(r.axisValue(1))
+	mid := r.formatValue(r.axisValue(0.5))
+
+	place(start, offset)
+	place(end, offset+r.barLen-utf8.RuneCountInString(end))
+	place(mid, offset+r.barLen/2-utf8.RuneCountInString(mid)/2)
+
+	return strings.TrimRight(string(line), " ")
+}
+
+// axisValue returns the value at a fraction of the bar region.
+func (r *Renderer) axisValue(frac float64) float64 {
+	switch r.scaleMode {
+	case ScaleLog:
+		return math.Round((math.Pow(r.maxVal+1, frac)-1)*100) / 100
+	case ScaleSymlog:
+		return math.Round((math.Pow(r.maxAbs+1, frac)-1)*100) / 100
+	}
+
+	return r.maxVal * frac
+}
+
+// longestValueLen returns the length of the longest formatted value.
+func (r *Renderer) longestValueLen(values []float64) int {
+	longest := 0
+
+	for _, value := range values {
+		longest = max(longest, utf8.RuneCountInString(r.value(value)))
+	}
+
+	return longest
 }
 
 // RendererOption configures a [Renderer].
@@ -150,9 +514,29 @@ func WithMaxLabelLength(n int) RendererOption {
 }
 
 // WithScaling configures a [Renderer] to scale chart bars logarithmically.
+//
+// Deprecated: Use [WithScaleMode] with [ScaleLog] instead.
 func WithScaling(enable bool) RendererOption {
+	if enable {
+		return WithScaleMode(ScaleLog)
+	}
+
+	return WithScaleMode(ScaleLinear)
+}
+
+// WithScaleMode configures a [Renderer] with a scale for bar lengths.
+//
+// Logarithmic scaling is only meaningful for non-negative values, so bars for
+// negative values are drawn as empty. Symmetric logarithmic scaling uses
+// sign(x) * log10(1+|x|) so zero maps to zero, and bars are drawn for the
+// magnitude of both positive and negative values.
+func WithScaleMode(mode ScaleMode) RendererOption {
 	return func(r *Renderer) error {
-		r.scale = enable
+		if mode != ScaleLinear && mode != ScaleLog && mode != ScaleSymlog {
+			return fmt.Errorf("unknown scale mode: %d", mode)
+		}
+
+		r.scaleMode = mode
 		return nil
 	}
 }
@@ -165,18 +549,289 @@ func WithTick(tick rune) RendererOption {
 	}
 }
 
+// WithMinBarGlyph configures a [Renderer] with a glyph to draw for bars that
+// round to zero length, regardless of the configured tick. Use 0 to draw
+// nothing.
+//
+// By default, a small tick is drawn, or a dot in ASCII mode.
+func WithMinBarGlyph(glyph rune) RendererOption {
+	return func(r *Renderer) error {
+		r.minGlyph = &glyph
+		return nil
+	}
+}
+
+// WithPartialBlocks configures a [Renderer] to draw the fractional remainder
+// of bars with partial block characters instead of rounding to whole ticks.
+//
+// Partial blocks are only drawn when the default tick is used.
+func WithPartialBlocks(enable bool) RendererOption {
+	return func(r *Renderer) error {
+		r.partial = enable
+		return nil
+	}
+}
+
+// WithColor configures a [Renderer] to draw bars in color.
+//
+// Colors are only drawn when writing to a terminal and the NO_COLOR
+// environment variable is not set.
+func WithColor(enable bool) RendererOption {
+	return func(r *Renderer) error {
+		r.color = enable
+		return nil
+	}
+}
+
+// WithHighlightMax configures a [Renderer] to draw the bar with the highest
+// value in bold, combined with its color from the palette or thresholds. If
+// multiple bars have the highest value, the first drawn bar is highlighted.
+//
+// Like colors, the highlight is only drawn when color is enabled with
+// [WithColor].
+func WithHighlightMax(enable bool) RendererOption {
+	return func(r *Renderer) error {
+		r.highlightMax = enable
+		return nil
+	}
+}
+
+// WithColorPalette configures a [Renderer] with a palette of 256-color codes
+// (e.g. "196" for red) to cycle through when drawing bars in color.
+func WithColorPalette(palette []string) RendererOption {
+	return func(r *Renderer) error {
+		if len(palette) == 0 {
+			return errors.New("color palette must not be empty")
+		}
+
+		r.palette = palette
+		return nil
+	}
+}
+
+// WithLabelAlign configures a [Renderer] with an alignment for labels.
+func WithLabelAlign(align Align) RendererOption {
+	return func(r *Renderer) error {
+		if align != AlignRight && align != AlignLeft {
+			return fmt.Errorf("unknown label alignment: %d", align)
+		}
+
+		r.labelAlign = align
+		return nil
+	}
+}
+
+// WithOrientation configures a [Renderer] to draw bars in a direction.
+//
+// Vertical bars are drawn as columns with labels beneath them, abbreviated to
+// the column width. Rendering fails if the columns can't fit within the
+// maximum chart length.
+func WithOrientation(o Orientation) RendererOption {
+	return func(r *Renderer) error {
+		if o != Horizontal && o != Vertical {
+			return fmt.Errorf("unknown orientation: %d", o)
+		}
+
+		r.orientation = o
+		return nil
+	}
+}
+
+// WithMaxHeight configures a [Renderer] with a maximum height in rows for
+// vertical bars.
+func WithMaxHeight(n int) RendererOption {
+	return func(r *Renderer) error {
+		if n <= 0 {
+			return errors.New("maximum height must be a positive integer")
+		}
+
+		r.maxHeight = n
+		return nil
+	}
+}
+
+// WithASCII configures a [Renderer] to draw bars with ASCII characters only,
+// for environments that can't display Unicode block elements. Bars are drawn
+// with # and remainders with . or |, overriding any configured tick.
+func WithASCII(enable bool) RendererOption {
+	return func(r *Renderer) error {
+		r.ascii = enable
+		return nil
+	}
+}
+
+// WithAxis configures a [Renderer] to write an axis line above the bars with
+// values for the start, middle, and end of the bar region.
+func WithAxis(enable bool) RendererOption {
+	return func(r *Renderer) error {
+		r.axis = enable
+		return nil
+	}
+}
+
+// WithFooter configures a [Renderer] to write a footer line below the bars
+// with the chart sum and number of labels.
+func WithFooter(enable bool) RendererOption {
+	return func(r *Renderer) error {
+		r.footer = enable
+		return nil
+	}
+}
+
+// WithFooterFunc configures a [Renderer] to write a footer line below the bars
+// with the string returned by fn.
+func WithFooterFunc(fn func(*chart.Chart) string) RendererOption {
+	return func(r *Renderer) error {
+		if fn == nil {
+			return errors.New("footer function must not be nil")
+		}
+
+		r.footer = true
+		r.footerFn = fn
+		return nil
+	}
+}
+
+// WithValueFormat configures a [Renderer] to format values with fn instead of
+// the default format with the chart precision.
+func WithValueFormat(fn func(float64) string) RendererOption {
+	return func(r *Renderer) error {
+		if fn == nil {
+			return errors.New("value format function must not be nil")
+		}
+
+		r.valueFmt = fn
+		return nil
+	}
+}
+
+// WithGrouping configures a [Renderer] to group digits of values with
+// thousands separators, e.g. 1,234,567.
+func WithGrouping(enable bool) RendererOption {
+	return func(r *Renderer) error {
+		r.grouping = enable
+		return nil
+	}
+}
+
+// WithLocale configures a [Renderer] to format values with the decimal and
+// group separators of a locale. Supported locales are en (default), de and fr.
+func WithLocale(name string) RendererOption {
+	return func(r *Renderer) error {
+		loc, ok := numfmt.Locales[name]
+		if !ok {
+			return fmt.Errorf("unknown locale %q", name)
+		}
+
+		r.locale = loc
+		return nil
+	}
+}
+
+// WithValueUnit configures a [Renderer] to display values with a unit suffix,
+// e.g. ms for values like 1234 ms.
+func WithValueUnit(suffix string) RendererOption {
+	return func(r *Renderer) error {
+		r.unit = suffix
+		return nil
+	}
+}
+
+// WithPercentages configures a [Renderer] to display each value with its
+// percentage of the chart sum, e.g. 25 (12.5%).
+func WithPercentages(enable bool) RendererOption {
+	return func(r *Renderer) error {
+		r.percentages = enable
+		return nil
+	}
+}
+
+// Threshold maps bar values to a color.
+type Threshold struct {
+	Min   float64 // Minimum value for the threshold to match (inclusive).
+	Color string  // 256-color code to use for matching bars.
+}
+
+// WithColorThresholds configures a [Renderer] to color bars by value instead
+// of cycling through the color palette.
+//
+// Thresholds are evaluated in order and the first threshold with a Min value
+// less than or equal to the bar value wins, so thresholds should be given in
+// descending order of Min. Bars with no matching threshold use the default
+// terminal color. Thresholds only have an effect when color is enabled with
+// [WithColor].
+func WithColorThresholds(thresholds []Threshold) RendererOption {
+	return func(r *Renderer) error {
+		r.thresholds = thresholds
+		return nil
+	}
+}
+
+// isTerminal reports whether w is a terminal.
+var isTerminal = func(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
 func truncate(s string, maxLen int) string {
-	sLen := utf8.RuneCountInString(s)
-	if sLen <= maxLen {
+	if displayWidth(s) <= maxLen {
 		return s
 	}
 
 	ellips := "..."
-	ellipsLen := len(ellips)
+	partLen := (maxLen - displayWidth(ellips)) / 2
+	runes := []rune(s)
+
+	start, width := 0, 0
+	for ; start < len(runes) && width+runeWidth(runes[start]) <= partLen; start++ {
+		width += runeWidth(runes[start])
+	}
+
+	end, width := len(runes), 0
+	for ; end > start && width+runeWidth(runes[end-1]) <= partLen; end-- {
+		width += runeWidth(runes[end-1])
+	}
+
+	return string(runes[:start]) + ellips + string(runes[end:])
+}
 
-	partLen := (maxLen - ellipsLen) / 2
-	start := s[:partLen]
-	end := s[sLen-partLen:]
+// longestWidth returns the display width of the widest string.
+func longestWidth(ss []string) int {
+	longest := 0
+	for _, s := range ss {
+		longest = max(longest, displayWidth(s))
+	}
+
+	return longest
+}
+
+// displayWidth returns the number of terminal columns needed to display s.
+func displayWidth(s string) int {
+	n := 0
+	for _, r := range s {
+		n += runeWidth(r)
+	}
+
+	return n
+}
 
-	return start + ellips + end
+// runeWidth returns the number of terminal columns needed to display r.
+// East Asian wide and fullwidth runes, like CJK characters and most emoji,
+// occupy two columns.
+func runeWidth(r rune) int {
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	default:
+		return 1
+	}
 }
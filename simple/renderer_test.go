@@ -0,0 +1,585 @@
+package simple_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/michenriksen/chart"
+	"github.com/michenriksen/chart/simple"
+)
+
+// BenchmarkRender renders a chart with 10k labels to a file, where each write
+// is a system call.
+func BenchmarkRender(b *testing.B) {
+	c, err := chart.New()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for i := range 10_000 {
+		c.Set("label"+strconv.Itoa(i), float64(i))
+	}
+
+	r, err := simple.NewRenderer()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	f, err := os.Create(filepath.Join(b.TempDir(), "chart.txt"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer f.Close()
+
+	b.ResetTimer()
+
+	for range b.N {
+		if _, err := r.Render(c, f); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestRenderASCIIPartialBlocks(t *testing.T) {
+	c, err := chart.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set("a", 8)
+	c.Set("b", 2.5)
+	c.Set("c", 1.125)
+	c.Set("d", 0)
+
+	tests := []struct {
+		name  string
+		ascii bool
+		want  string
+	}{
+		{"unicode", false, "a ▇▇▇▇▇▇▇▇ 8.00\nb ▇▇▌ 2.50\nc ▇▏ 1.13\nd ▏ 0.00\n"},
+		{"ascii", true, "a ######## 8.00\nb ##| 2.50\nc #. 1.13\nd . 0.00\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := simple.NewRenderer(
+				simple.WithMaxLength(15),
+				simple.WithPartialBlocks(true),
+				simple.WithASCII(tt.ascii),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var sb strings.Builder
+
+			if _, err := r.Render(c, &sb); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := sb.String(); got != tt.want {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRenderScalingNonPositive(t *testing.T) {
+	c, err := chart.New(chart.WithPrecision(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set("a", 99)
+	c.Set("b", 9)
+	c.Set("c", 0)
+	c.Set("d", -1)
+	c.Set("e", -5)
+
+	r, err := simple.NewRenderer(simple.WithMaxLength(14), simple.WithScaling(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var sb strings.Builder
+
+	if _, err := r.Render(c, &sb); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "a ▇▇▇▇▇▇▇▇▇ 99\nb ▇▇▇▇▇ 9\nc ▏ 0\nd ▏ -1\ne ▏ -5\n"
+	if got := sb.String(); got != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestRenderScaleModes(t *testing.T) {
+	c, err := chart.New(chart.WithPrecision(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set("a", 99)
+	c.Set("b", 9)
+	c.Set("c", 0)
+	c.Set("d", -9)
+	c.Set("e", -99)
+
+	tests := []struct {
+		name string
+		mode simple.ScaleMode
+		want string
+	}{
+		{"linear", simple.ScaleLinear, "a ▇▇▇▇▇▇▇▇▇ 99\nb ▇ 9\nc ▏ 0\nd ▏ -9\ne ▏ -99\n"},
+		{"log", simple.ScaleLog, "a ▇▇▇▇▇▇▇▇▇ 99\nb ▇▇▇▇▇ 9\nc ▏ 0\nd ▏ -9\ne ▏ -99\n"},
+		{"symlog", simple.ScaleSymlog, "a ▇▇▇▇▇▇▇▇▇ 99\nb ▇▇▇▇▇ 9\nc ▏ 0\nd ▇▇▇▇▇ -9\ne ▇▇▇▇▇▇▇▇▇ -99\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := simple.NewRenderer(simple.WithMaxLength(15), simple.WithScaleMode(tt.mode))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var sb strings.Builder
+
+			if _, err := r.Render(c, &sb); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := sb.String(); got != tt.want {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWithScaleModeUnknown(t *testing.T) {
+	if _, err := simple.NewRenderer(simple.WithScaleMode(simple.ScaleMode(42))); err == nil {
+		t.Error("expected error for unknown scale mode")
+	}
+}
+
+func TestRenderPrecision(t *testing.T) {
+	tests := []struct {
+		prec int
+		want string
+	}{
+		{0, " 1\n"},
+		{2, " 1.23\n"},
+		{4, " 1.2346\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.prec), func(t *testing.T) {
+			c, err := chart.New(chart.WithPrecision(tt.prec))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			c.Set("a", 1.23456)
+
+			r, err := simple.NewRenderer()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var sb strings.Builder
+
+			if _, err := r.Render(c, &sb); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := sb.String(); !strings.HasSuffix(got, tt.want) {
+				t.Errorf("expected output ending with %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRenderLocale(t *testing.T) {
+	tests := []struct {
+		locale string
+		want   string
+	}{
+		{"en", " 1,234,567.89\n"},
+		{"de", " 1.234.567,89\n"},
+	}
+
+	c, err := chart.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set("a", 1234567.891)
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			r, err := simple.NewRenderer(simple.WithLocale(tt.locale), simple.WithGrouping(true))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var sb strings.Builder
+
+			if _, err := r.Render(c, &sb); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := sb.String(); !strings.HasSuffix(got, tt.want) {
+				t.Errorf("expected output ending with %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWithLocaleUnknown(t *testing.T) {
+	if _, err := simple.NewRenderer(simple.WithLocale("xx")); err == nil {
+		t.Error("expected error for unknown locale")
+	}
+}
+
+func TestRenderHighlightMax(t *testing.T) {
+	c, err := chart.New(chart.WithPrecision(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set("a", 4)
+	c.Set("b", 8)
+	c.Set("c", 8)
+
+	tests := []struct {
+		name  string
+		color bool
+		want  string
+	}{
+		{
+			name:  "color",
+			color: true,
+			want: "a \033[38;5;1m▇▇\033[0m 4\n" +
+				"b \033[1;38;5;1m▇▇▇▇\033[0m 8\n" +
+				"c \033[38;5;1m▇▇▇▇\033[0m 8\n",
+		},
+		{
+			name: "no color",
+			want: "a ▇▇ 4\nb ▇▇▇▇ 8\nc ▇▇▇▇ 8\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			simple.ForceTerminal(t)
+
+			r, err := simple.NewRenderer(
+				simple.WithMaxLength(8),
+				simple.WithColor(tt.color),
+				simple.WithColorPalette([]string{"1"}),
+				simple.WithHighlightMax(true),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var sb strings.Builder
+
+			if _, err := r.Render(c, &sb); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := sb.String(); got != tt.want {
+				t.Errorf("expected:\n%q\ngot:\n%q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRenderFooterHighlightConcurrent(t *testing.T) {
+	c, err := chart.New(chart.WithPrecision(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range 10 {
+		c.Set("label "+strconv.Itoa(i), 0)
+	}
+
+	simple.ForceTerminal(t)
+
+	r, err := simple.NewRenderer(
+		simple.WithColor(true),
+		simple.WithColorPalette([]string{"1"}),
+		simple.WithHighlightMax(true),
+		simple.WithFooter(true),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		for i := 0; ; i++ {
+			select {
+			case <-done:
+				return
+			default:
+				c.Set("label "+strconv.Itoa(i%10), float64(i%97))
+			}
+		}
+	}()
+
+	for range 1000 {
+		var sb strings.Builder
+
+		if _, err := r.Render(c, &sb); err != nil {
+			t.Fatal(err)
+		}
+
+		lines := strings.Split(strings.TrimSuffix(sb.String(), "\n"), "\n")
+		bars, footer := lines[:len(lines)-1], lines[len(lines)-1]
+
+		var sum, maxVal, maxBar int
+
+		for i, bar := range bars {
+			val, err := strconv.Atoi(bar[strings.LastIndexByte(bar, ' ')+1:])
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if sum += val; i == 0 || val > maxVal {
+				maxVal, maxBar = val, i
+			}
+		}
+
+		if want := fmt.Sprintf("Total: %d (%d labels)", sum, len(bars)); footer != want {
+			t.Fatalf("expected footer %q, got %q", want, footer)
+		}
+
+		for i, bar := range bars {
+			if highlighted := strings.Contains(bar, "\033[1;"); highlighted != (i == maxBar) {
+				t.Fatalf("expected bar #%d to be highlighted, got bar #%d: %q", maxBar+1, i+1, bar)
+			}
+		}
+	}
+}
+
+// countdownContext is a context that is canceled after its Err method has
+// been called n times.
+type countdownContext struct {
+	context.Context
+	n int
+}
+
+func (c *countdownContext) Err() error {
+	if c.n--; c.n < 0 {
+		return context.Canceled
+	}
+
+	return nil
+}
+
+func TestRenderContextCanceled(t *testing.T) {
+	c, err := chart.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range 100_000 {
+		c.Set("label "+strconv.Itoa(i), float64(i))
+	}
+
+	r, err := simple.NewRenderer()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := &countdownContext{Context: context.Background(), n: 100}
+
+	var sb strings.Builder
+
+	n, err := r.RenderContext(ctx, c, &sb)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context canceled error, got %v", err)
+	}
+
+	if n != 0 || sb.Len() != 0 {
+		t.Errorf("expected nothing to be written, got %d bytes", sb.Len())
+	}
+
+	if ctx.n != -1 {
+		t.Errorf("expected rendering to stop when canceled, context checked %d more times", -1-ctx.n)
+	}
+}
+
+func TestRenderColor(t *testing.T) {
+	c, err := chart.New(chart.WithPrecision(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set("a", 4)
+	c.Set("b", 8)
+	c.Set("c", 2)
+
+	colored := "a \033[38;5;1m▇▇\033[0m 4\n" +
+		"b \033[38;5;2m▇▇▇▇\033[0m 8\n" +
+		"c \033[38;5;1m▇\033[0m 2\n"
+	plain := "a ▇▇ 4\nb ▇▇▇▇ 8\nc ▇ 2\n"
+
+	tests := []struct {
+		name     string
+		terminal bool
+		noColor  string
+		want     string
+	}{
+		{"terminal", true, "", colored},
+		{"not terminal", false, "", plain},
+		{"NO_COLOR set", true, "1", plain},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.terminal {
+				simple.ForceTerminal(t)
+			}
+
+			t.Setenv("NO_COLOR", tt.noColor)
+
+			r, err := simple.NewRenderer(
+				simple.WithMaxLength(8),
+				simple.WithColor(true),
+				simple.WithColorPalette([]string{"1", "2"}),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var sb strings.Builder
+
+			if _, err := r.Render(c, &sb); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := sb.String(); got != tt.want {
+				t.Errorf("expected:\n%q\ngot:\n%q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRenderColorThresholds(t *testing.T) {
+	c, err := chart.New(chart.WithPrecision(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set("a", 10)
+	c.Set("b", 8)
+	c.Set("c", 2)
+
+	thresholds := []simple.Threshold{
+		{Min: 10, Color: "1"},
+		{Min: 5, Color: "2"},
+	}
+
+	tests := []struct {
+		name  string
+		color bool
+		want  string
+	}{
+		{
+			name:  "color",
+			color: true,
+			want: "a \033[38;5;1m▇▇▇▇▇▇▇▇\033[0m 10\n" +
+				"b \033[38;5;2m▇▇▇▇▇▇\033[0m 8\n" +
+				"c ▇▇ 2\n",
+		},
+		{
+			name: "no color",
+			want: "a ▇▇▇▇▇▇▇▇ 10\nb ▇▇▇▇▇▇ 8\nc ▇▇ 2\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			simple.ForceTerminal(t)
+
+			r, err := simple.NewRenderer(
+				simple.WithMaxLength(13),
+				simple.WithColor(tt.color),
+				simple.WithColorThresholds(thresholds),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var sb strings.Builder
+
+			if _, err := r.Render(c, &sb); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := sb.String(); got != tt.want {
+				t.Errorf("expected:\n%q\ngot:\n%q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRenderSeries(t *testing.T) {
+	c, err := chart.New(chart.WithPrecision(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.SetSeries("a", "x", 1).SetSeries("a", "y", 1).SetSeries("b", "x", 4)
+
+	r, err := simple.NewRenderer(simple.WithMaxLength(8))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var sb strings.Builder
+
+	if _, err := r.Render(c, &sb); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := sb.String(), "a ▇▇ 2\nb ▇▇▇▇ 4\n"; got != want {
+		t.Errorf("expected:\n%q\ngot:\n%q", want, got)
+	}
+}
+
+func TestRenderNarrowLength(t *testing.T) {
+	c, err := chart.New(chart.WithPrecision(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set("requests", 1200)
+	c.Set("errors", -3)
+
+	r, err := simple.NewRenderer(simple.WithMaxLength(5), simple.WithAxis(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var sb strings.Builder
+
+	if _, err := r.Render(c, &sb); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := sb.String(), "requests ▏ 1200\n  errors ▏ -3\n"; got != want {
+		t.Errorf("expected:\n%q\ngot:\n%q", want, got)
+	}
+}
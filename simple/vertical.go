@@ -0,0 +1,115 @@
+package simple
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// fullBlock is the tick for drawing full cells of vertical bars.
+const fullBlock = '█'
+
+// verticalTicks are the ticks for drawing the top of vertical bars in eighths,
+// from one eighth to seven eighths.
+var verticalTicks = []rune("▁▂▃▄▅▆▇")
+
+// writeVertical writes values as vertical columns to buf with labels beneath
+// each column.
+func (r *Renderer) writeVertical(ctx context.Context, labels []string, values []float64, buf *bytes.Buffer) error {
+	if len(labels) == 0 {
+		return nil
+	}
+
+	// Each column is followed by a single space separator.
+	colWidth := min(longestWidth(labels), r.maxLabelLen, r.maxLen/len(labels)-1)
+	if colWidth < 1 {
+		return fmt.Errorf("%d columns don't fit within maximum length of %d", len(labels), r.maxLen)
+	}
+
+	heights := make([]int, len(values))
+	for i, value := range values {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("rendering chart: %w", err)
+		}
+
+		heights[i] = r.columnHeight(value)
+	}
+
+	for row := r.maxHeight - 1; row >= 0; row-- {
+		var line strings.Builder
+
+		for i, height := range heights {
+			cell := strings.Repeat(string(r.columnCell(height-row*8)), colWidth)
+			if strings.TrimSpace(cell) != "" {
+				cell = r.paint(cell, r.barColor(i, values[i]), r.isHighlighted(labels[i]))
+			}
+
+			line.WriteString(cell + " ")
+		}
+
+		fmt.Fprintln(buf, strings.TrimRight(line.String(), " "))
+	}
+
+	var line strings.Builder
+	for _, label := range labels {
+		label = abbreviate(label, colWidth)
+		line.WriteString(label + strings.Repeat(" ", colWidth-displayWidth(label)+1))
+	}
+
+	fmt.Fprintln(buf, strings.TrimRight(line.String(), " "))
+
+	return nil
+}
+
+// columnHeight returns the height of a column for value in eighths of a row.
+func (r *Renderer) columnHeight(value float64) int {
+	height := r.fraction(value) * float64(r.maxHeight*8)
+
+	if r.ascii || r.tick != DefaultTick {
+		// Custom and ASCII ticks can't be drawn partially, so round to whole
+		// rows.
+		return int(math.Round(height/8)) * 8
+	}
+
+	return int(math.Round(height))
+}
+
+// columnCell returns the tick for a cell of a column filled by the given
+// number of eighths.
+func (r *Renderer) columnCell(eighths int) rune {
+	tick, _, _ := r.ticks()
+
+	switch {
+	case eighths <= 0:
+		return ' '
+	case eighths >= 8 && tick == DefaultTick:
+		return fullBlock
+	case eighths >= 8:
+		return tick
+	default:
+		return verticalTicks[eighths-1]
+	}
+}
+
+// abbreviate shortens s to fit within maxWidth display columns.
+func abbreviate(s string, maxWidth int) string {
+	if displayWidth(s) <= maxWidth {
+		return s
+	}
+
+	var b strings.Builder
+
+	width := 0
+	for _, r := range s {
+		if width+runeWidth(r) > maxWidth {
+			break
+		}
+
+		b.WriteRune(r)
+		width += runeWidth(r)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,73 @@
+package sparkline
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/michenriksen/chart"
+)
+
+// ticks are the block characters for values from lowest to highest.
+var ticks = []rune("▁▂▃▄▅▆▇█")
+
+// Renderer renders a [chart.Chart] as a single-line sparkline.
+type Renderer struct{}
+
+// NewRenderer returns a [chart.Renderer] for rendering a [chart.Chart] as a
+// sparkline.
+func NewRenderer(opts ...RendererOption) (*Renderer, error) {
+	r := &Renderer{}
+
+	for i, opt := range opts {
+		if err := opt(r); err != nil {
+			return nil, fmt.Errorf("applying option #%d: %w", i+1, err)
+		}
+	}
+
+	return r, nil
+}
+
+// Render renders chart to out writer.
+//
+// Each value is drawn as one of eight blocks with a height relative to the
+// chart's maximum value. Zero and negative values are drawn with the lowest
+// block. Labels are omitted.
+func (r *Renderer) Render(c *chart.Chart, out io.Writer) (int, error) {
+	_, values := c.Snapshot()
+
+	maxVal := 0.0
+	for _, value := range values {
+		maxVal = max(maxVal, value)
+	}
+
+	var b strings.Builder
+
+	for _, value := range values {
+		b.WriteRune(r.tick(value, maxVal))
+	}
+
+	b.WriteByte('\n')
+
+	n, err := io.WriteString(out, b.String())
+	if err != nil {
+		return n, fmt.Errorf("writing to out: %w", err)
+	}
+
+	return n, nil
+}
+
+// tick returns the block for value relative to maxVal.
+func (*Renderer) tick(value, maxVal float64) rune {
+	if maxVal <= 0 || value <= 0 {
+		return ticks[0]
+	}
+
+	i := int(math.Round(value / maxVal * float64(len(ticks)-1)))
+
+	return ticks[min(i, len(ticks)-1)]
+}
+
+// RendererOption configures a [Renderer].
+type RendererOption func(*Renderer) error
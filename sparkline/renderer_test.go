@@ -0,0 +1,84 @@
+package sparkline_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/michenriksen/chart"
+	"github.com/michenriksen/chart/sparkline"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		want   string
+	}{
+		{
+			name: "empty",
+			want: "\n",
+		},
+		{
+			name:   "single value",
+			values: []float64{3},
+			want:   "█\n",
+		},
+		{
+			name:   "all equal",
+			values: []float64{5, 5, 5},
+			want:   "███\n",
+		},
+		{
+			name:   "every block",
+			values: []float64{0, 1, 2, 3, 4, 5, 6, 7},
+			want:   "▁▂▃▄▅▆▇█\n",
+		},
+		{
+			name:   "min and max endpoints",
+			values: []float64{100, 1, 50},
+			want:   "█▁▅\n",
+		},
+		{
+			name:   "zero and negative",
+			values: []float64{-5, 0, 10},
+			want:   "▁▁█\n",
+		},
+		{
+			name:   "all zero",
+			values: []float64{0, 0},
+			want:   "▁▁\n",
+		},
+		{
+			name:   "all negative",
+			values: []float64{-1, -10},
+			want:   "▁▁\n",
+		},
+	}
+
+	r, err := sparkline.NewRenderer()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := chart.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for i, value := range tt.values {
+				c.Set(strconv.Itoa(i), value)
+			}
+
+			got, err := chart.RenderString(r, c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package svg
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/michenriksen/chart"
+)
+
+// Default option values.
+const (
+	DefaultWidth     = 800
+	DefaultBarHeight = 20
+)
+
+// Layout measurements in pixels.
+const (
+	padding     = 10
+	barGap      = 4
+	charWidth   = 7 // Approximate width of a character at the chart font size.
+	fontSize    = 12
+	titleHeight = 30
+	titleSize   = 16
+)
+
+var defaultColors = []string{"#4e79a7"}
+
+// Renderer renders a [chart.Chart] as a horizontal SVG bar chart.
+type Renderer struct {
+	title     string
+	width     int
+	barHeight int
+	colors    []string
+}
+
+// NewRenderer returns a [chart.Renderer] for rendering a [chart.Chart] as a
+// horizontal SVG bar chart.
+func NewRenderer(opts ...RendererOption) (*Renderer, error) {
+	r := &Renderer{
+		width:     DefaultWidth,
+		barHeight: DefaultBarHeight,
+		colors:    defaultColors,
+	}
+
+	for i, opt := range opts {
+		if err := opt(r); err != nil {
+			return nil, fmt.Errorf("applying option #%d: %w", i+1, err)
+		}
+	}
+
+	return r, nil
+}
+
+// Render renders chart to out writer.
+func (r *Renderer) Render(c *chart.Chart, out io.Writer) (int, error) {
+	labels, values := c.Snapshot()
+
+	maxVal := 0.0
+	for _, value := range values {
+		maxVal = max(maxVal, value)
+	}
+
+	labelWidth := longestLabelLen(labels)*charWidth + padding
+	valueWidth := longestValueLen(values, c.Precision())*charWidth + padding
+	barArea := max(r.width-labelWidth-valueWidth-2*padding, 0)
+	barX := padding + labelWidth
+
+	top := padding
+	if r.title != "" {
+		top += titleHeight
+	}
+
+	height := top + padding
+	if len(labels) > 0 {
+		height += len(labels)*(r.barHeight+barGap) - barGap
+	}
+
+	buf := new(bytes.Buffer)
+
+	fmt.Fprintf(buf,
+		"<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\" "+
+			"font-family=\"sans-serif\" font-size=\"%d\">\n",
+		r.width, height, r.width, height, fontSize,
+	)
+
+	if r.title != "" {
+		fmt.Fprintf(buf,
+			"  <text x=\"%d\" y=\"%d\" text-anchor=\"middle\" font-size=\"%d\" font-weight=\"bold\">%s</text>\n",
+			r.width/2, padding+titleSize, titleSize, html.EscapeString(r.title),
+		)
+	}
+
+	for i, label := range labels {
+		value := values[i]
+
+		barWidth := 0.0
+		if maxVal > 0 {
+			barWidth = max(value, 0) / maxVal * float64(barArea)
+		}
+
+		y := top + i*(r.barHeight+barGap)
+		textY := y + r.barHeight/2
+
+		fmt.Fprintf(buf, "  <text x=\"%d\" y=\"%d\" text-anchor=\"end\" dominant-baseline=\"middle\">%s</text>\n",
+			barX-padding/2, textY, html.EscapeString(label),
+		)
+		fmt.Fprintf(buf, "  <rect x=\"%d\" y=\"%d\" width=\"%s\" height=\"%d\" fill=\"%s\"/>\n",
+			barX, y, formatFloat(barWidth), r.barHeight, html.EscapeString(r.colors[i%len(r.colors)]),
+		)
+		fmt.Fprintf(buf, "  <text x=\"%s\" y=\"%d\" dominant-baseline=\"middle\">%s</text>\n",
+			formatFloat(float64(barX)+barWidth+padding/2), textY, strconv.FormatFloat(value, 'f', c.Precision(), 64),
+		)
+	}
+
+	fmt.Fprintln(buf, "</svg>")
+
+	n, err := out.Write(buf.Bytes())
+	if err != nil {
+		return n, fmt.Errorf("writing to out: %w", err)
+	}
+
+	return n, nil
+}
+
+// RendererOption configures a [Renderer].
+type RendererOption func(*Renderer) error
+
+// WithTitle configures a [Renderer] with a chart title.
+func WithTitle(title string) RendererOption {
+	return func(r *Renderer) error {
+		r.title = title
+		return nil
+	}
+}
+
+// WithWidth configures a [Renderer] with a total chart width in pixels.
+func WithWidth(n int) RendererOption {
+	return func(r *Renderer) error {
+		if n <= 0 {
+			return errors.New("width must be a positive integer")
+		}
+
+		r.width = n
+		return nil
+	}
+}
+
+// WithBarHeight configures a [Renderer] with a bar height in pixels.
+func WithBarHeight(n int) RendererOption {
+	return func(r *Renderer) error {
+		if n <= 0 {
+			return errors.New("bar height must be a positive integer")
+		}
+
+		r.barHeight = n
+		return nil
+	}
+}
+
+// WithColors configures a [Renderer] with colors to cycle through when drawing
+// bars. Colors can be any value accepted by the SVG fill attribute.
+func WithColors(colors []string) RendererOption {
+	return func(r *Renderer) error {
+		if len(colors) == 0 {
+			return errors.New("colors must not be empty")
+		}
+
+		r.colors = colors
+		return nil
+	}
+}
+
+// longestLabelLen returns the length of the longest label, measured in runes.
+func longestLabelLen(labels []string) int {
+	n := 0
+	for _, label := range labels {
+		n = max(n, utf8.RuneCountInString(label))
+	}
+
+	return n
+}
+
+// longestValueLen returns the length of the longest value formatted with
+// precision prec, including the sign of negative values.
+func longestValueLen(values []float64, prec int) int {
+	n := 0
+	for _, val := range values {
+		n = max(n, len(strconv.FormatFloat(val, 'f', prec, 64)))
+	}
+
+	return n
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(math.Round(f*100)/100, 'f', -1, 64)
+}
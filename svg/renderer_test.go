@@ -0,0 +1,113 @@
+package svg_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/michenriksen/chart"
+	"github.com/michenriksen/chart/svg"
+)
+
+func TestRender(t *testing.T) {
+	c, err := chart.New(chart.WithPrecision(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set("a&b", -1234).Set("c", 5)
+
+	r, err := svg.NewRenderer(svg.WithWidth(200), svg.WithTitle("<T>"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := chart.RenderString(r, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The value column is wide enough for the negative value, so the longest
+	// bar and its value fit within the chart width.
+	want := `<svg xmlns="http://www.w3.org/2000/svg" width="200" height="94" viewBox="0 0 200 94" font-family="sans-serif" font-size="12">
+  <text x="100" y="26" text-anchor="middle" font-size="16" font-weight="bold">&lt;T&gt;</text>
+  <text x="36" y="50" text-anchor="end" dominant-baseline="middle">a&amp;b</text>
+  <rect x="41" y="40" width="0" height="20" fill="#4e79a7"/>
+  <text x="46" y="50" dominant-baseline="middle">-1234</text>
+  <text x="36" y="74" text-anchor="end" dominant-baseline="middle">c</text>
+  <rect x="41" y="64" width="104" height="20" fill="#4e79a7"/>
+  <text x="150" y="74" dominant-baseline="middle">5</text>
+</svg>
+`
+
+	if got != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestRenderOptions(t *testing.T) {
+	c, err := chart.New(chart.WithPrecision(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set("a", 1).Set("b", 2).Set("c", 3)
+
+	tests := []struct {
+		name string
+		opts []svg.RendererOption
+		want []string
+	}{
+		{
+			name: "defaults",
+			want: []string{
+				`width="800" height="88" viewBox="0 0 800 88"`,
+				`<rect x="27" y="58" width="746" height="20" fill="#4e79a7"/>`,
+			},
+		},
+		{
+			name: "bar height",
+			opts: []svg.RendererOption{svg.WithBarHeight(10)},
+			want: []string{
+				`width="800" height="58" viewBox="0 0 800 58"`,
+				`<rect x="27" y="38" width="746" height="10" fill="#4e79a7"/>`,
+			},
+		},
+		{
+			name: "width",
+			opts: []svg.RendererOption{svg.WithWidth(100)},
+			want: []string{
+				`width="100" height="88" viewBox="0 0 100 88"`,
+				`<rect x="27" y="58" width="46" height="20" fill="#4e79a7"/>`,
+			},
+		},
+		{
+			name: "colors",
+			opts: []svg.RendererOption{svg.WithColors([]string{"red", "\"blue\""})},
+			want: []string{
+				`<rect x="27" y="10" width="248.67" height="20" fill="red"/>`,
+				`<rect x="27" y="34" width="497.33" height="20" fill="&#34;blue&#34;"/>`,
+				`<rect x="27" y="58" width="746" height="20" fill="red"/>`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := svg.NewRenderer(tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := chart.RenderString(r, c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, s := range tt.want {
+				if !strings.Contains(got, s) {
+					t.Errorf("expected output to contain %q, got:\n%s", s, got)
+				}
+			}
+		})
+	}
+}
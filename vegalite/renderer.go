@@ -0,0 +1,161 @@
+package vegalite
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/michenriksen/chart"
+)
+
+// Schema is the URL of the Vega-Lite JSON schema that rendered specifications
+// conform to.
+const Schema = "https://vega.github.io/schema/vega-lite/v5.json"
+
+// Renderer renders a [chart.Chart] as a Vega-Lite specification for a
+// horizontal bar chart with inline data.
+//
+// See: https://vega.github.io/vega-lite/
+type Renderer struct {
+	title  string
+	width  int
+	height int
+	color  string
+}
+
+// NewRenderer returns a [chart.Renderer] for rendering a [chart.Chart] as a
+// Vega-Lite specification.
+func NewRenderer(opts ...RendererOption) (*Renderer, error) {
+	r := &Renderer{}
+
+	for i, opt := range opts {
+		if err := opt(r); err != nil {
+			return nil, fmt.Errorf("applying option #%d: %w", i+1, err)
+		}
+	}
+
+	return r, nil
+}
+
+// spec represents a Vega-Lite specification.
+type spec struct {
+	Schema   string   `json:"$schema"`
+	Title    string   `json:"title,omitempty"`
+	Width    int      `json:"width,omitempty"`
+	Height   int      `json:"height,omitempty"`
+	Data     data     `json:"data"`
+	Mark     mark     `json:"mark"`
+	Encoding encoding `json:"encoding"`
+}
+
+// data represents inline data of a Vega-Lite specification.
+type data struct {
+	Values []datum `json:"values"`
+}
+
+// datum represents a single inline data value.
+type datum struct {
+	Label string  `json:"label"`
+	Value float64 `json:"value"`
+}
+
+// mark represents the mark of a Vega-Lite specification.
+type mark struct {
+	Type  string `json:"type"`
+	Color string `json:"color,omitempty"`
+}
+
+// encoding represents the encoding of a Vega-Lite specification.
+type encoding struct {
+	X channel `json:"x"`
+	Y channel `json:"y"`
+}
+
+// channel represents an encoding channel of a Vega-Lite specification.
+type channel struct {
+	Field string `json:"field"`
+	Type  string `json:"type"`
+	Title string `json:"title,omitempty"`
+	// Sort is always encoded to keep the chart's label order, as a null sort
+	// disables Vega-Lite's default sorting.
+	Sort *string `json:"sort"`
+}
+
+// Render renders chart to out writer.
+func (r *Renderer) Render(c *chart.Chart, out io.Writer) (int, error) {
+	labels, values := c.Snapshot()
+	data := data{Values: make([]datum, len(labels))}
+
+	for i, label := range labels {
+		data.Values[i] = datum{Label: label, Value: values[i]}
+	}
+
+	s := spec{
+		Schema: Schema,
+		Title:  r.title,
+		Width:  r.width,
+		Height: r.height,
+		Data:   data,
+		Mark:   mark{Type: "bar", Color: r.color},
+		Encoding: encoding{
+			X: channel{Field: "value", Type: "quantitative", Title: "Value"},
+			Y: channel{Field: "label", Type: "nominal", Title: "Label"},
+		},
+	}
+
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return 0, fmt.Errorf("encoding specification: %w", err)
+	}
+
+	n, err := out.Write(append(b, '\n'))
+	if err != nil {
+		return n, fmt.Errorf("writing to out: %w", err)
+	}
+
+	return n, nil
+}
+
+// RendererOption configures a [Renderer].
+type RendererOption func(*Renderer) error
+
+// WithTitle configures a [Renderer] with a chart title.
+func WithTitle(title string) RendererOption {
+	return func(r *Renderer) error {
+		r.title = title
+		return nil
+	}
+}
+
+// WithWidth configures a [Renderer] with a chart width in pixels.
+func WithWidth(n int) RendererOption {
+	return func(r *Renderer) error {
+		if n <= 0 {
+			return errors.New("width must be a positive integer")
+		}
+
+		r.width = n
+		return nil
+	}
+}
+
+// WithHeight configures a [Renderer] with a chart height in pixels.
+func WithHeight(n int) RendererOption {
+	return func(r *Renderer) error {
+		if n <= 0 {
+			return errors.New("height must be a positive integer")
+		}
+
+		r.height = n
+		return nil
+	}
+}
+
+// WithColor configures a [Renderer] with a CSS color for bars, e.g. #4e79a7.
+func WithColor(color string) RendererOption {
+	return func(r *Renderer) error {
+		r.color = color
+		return nil
+	}
+}
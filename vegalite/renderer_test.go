@@ -0,0 +1,45 @@
+package vegalite_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/michenriksen/chart"
+	"github.com/michenriksen/chart/vegalite"
+)
+
+func TestRenderRequiredFields(t *testing.T) {
+	c, err := chart.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set("apples", 10).Set("oranges", 20)
+
+	r, err := vegalite.NewRenderer()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := new(bytes.Buffer)
+	if _, err := r.Render(c, buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var spec map[string]json.RawMessage
+	if err := json.Unmarshal(buf.Bytes(), &spec); err != nil {
+		t.Fatalf("expected valid JSON: %v\n%s", err, buf)
+	}
+
+	for _, field := range []string{"$schema", "data", "mark", "encoding"} {
+		if _, ok := spec[field]; !ok {
+			t.Errorf("expected specification to have %q field", field)
+		}
+	}
+
+	var schema string
+	if err := json.Unmarshal(spec["$schema"], &schema); err != nil || schema != vegalite.Schema {
+		t.Errorf("expected $schema to be %q, got %s", vegalite.Schema, spec["$schema"])
+	}
+}